@@ -0,0 +1,271 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/wardonne/gopi/container"
+	"github.com/wardonne/gopi/contract"
+	"github.com/wardonne/gopi/web/router"
+	"github.com/wardonne/gopi/workerpool"
+)
+
+// Default application settings
+var (
+	DefaultAddr            = ":8080"
+	DefaultShutdownTimeout = 30 * time.Second
+)
+
+var (
+	// ErrAlreadyStarted the application is started
+	ErrAlreadyStarted = errors.New("application is already started")
+	// ErrNotStarted the application is not started
+	ErrNotStarted = errors.New("application is not started")
+)
+
+// Application application kernel, which boots service providers and runs the http server and worker pools
+//
+//	application := app.New(app.WithRouter(r), app.WithPools(pools))
+//	application.Register(config.NewProvider(config.New(), "app"), logger.NewProvider())
+//	if err := application.Run(); err != nil {
+//		log.Fatal(err)
+//	}
+type Application struct {
+	container       *container.Container
+	router          *router.Router
+	pools           *workerpool.Manager
+	addr            string
+	shutdownTimeout time.Duration
+	signals         []os.Signal
+
+	mu       sync.Mutex
+	hooks    map[Stage][]Hook
+	bootOnce sync.Once
+	bootErr  error
+	started  bool
+	listener net.Listener
+	serveErr chan error
+}
+
+// New creates a new [Application] instance
+func New(opts ...Option) *Application {
+	app := &Application{
+		addr:            DefaultAddr,
+		shutdownTimeout: DefaultShutdownTimeout,
+		signals:         []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+		hooks:           make(map[Stage][]Hook),
+	}
+	for _, opt := range opts {
+		opt(app)
+	}
+	if app.container == nil {
+		app.container = container.New()
+	}
+	return app
+}
+
+// Container returns the dependency injection container
+func (app *Application) Container() *container.Container {
+	return app.container
+}
+
+// Router returns the router
+func (app *Application) Router() *router.Router {
+	return app.router
+}
+
+// Pools returns the worker pool manager
+func (app *Application) Pools() *workerpool.Manager {
+	return app.pools
+}
+
+// Addr returns the listening address of the http server, it's nil before the application is started
+func (app *Application) Addr() net.Addr {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	if app.listener == nil {
+		return nil
+	}
+	return app.listener.Addr()
+}
+
+// Register registers service providers in order
+func (app *Application) Register(providers ...contract.ServiceProvider) error {
+	return app.container.Provide(providers...)
+}
+
+// Boot boots the registered service providers, the application is booted only once
+//
+// the application, router and worker pool manager are registered into the container before booting.
+func (app *Application) Boot(ctx context.Context) error {
+	app.bootOnce.Do(func() {
+		container.Instance(app.container, app)
+		if app.router != nil {
+			container.Instance(app.container, app.router)
+			if app.router.Container() == nil {
+				app.router.SetContainer(app.container)
+			}
+		}
+		if app.pools != nil {
+			container.Instance(app.container, app.pools)
+		}
+		if app.bootErr = app.fire(ctx, Booting); app.bootErr != nil {
+			return
+		}
+		if app.bootErr = app.container.Boot(); app.bootErr != nil {
+			return
+		}
+		app.bootErr = app.fire(ctx, Booted)
+	})
+	return app.bootErr
+}
+
+// Start boots the application, starts the worker pools and the http server without blocking
+//
+// the http server and the worker pools are stopped again if the starting or started hooks fail.
+func (app *Application) Start(ctx context.Context) error {
+	if err := app.Boot(ctx); err != nil {
+		return err
+	}
+	app.mu.Lock()
+	if app.started {
+		app.mu.Unlock()
+		return ErrAlreadyStarted
+	}
+	app.started = true
+	app.mu.Unlock()
+	// listens before starting anything, so that nothing is left running when the address is unavailable
+	var listener net.Listener
+	if app.router != nil {
+		var err error
+		if listener, err = net.Listen("tcp", app.addr); err != nil {
+			app.abortStart(nil)
+			return err
+		}
+	}
+	if err := app.fire(ctx, Starting); err != nil {
+		app.abortStart(listener)
+		return err
+	}
+	if app.pools != nil {
+		for name := range app.pools.List() {
+			app.pools.Start(name)
+		}
+	}
+	if listener != nil {
+		app.mu.Lock()
+		app.listener = listener
+		app.serveErr = make(chan error, 1)
+		app.mu.Unlock()
+		// creates the server before serving, so that shutting down right after starting isn't missed
+		app.router.Server()
+		go func() {
+			app.serveErr <- app.router.Serve(listener)
+		}()
+	}
+	if err := app.fire(ctx, Started); err != nil {
+		return errors.Join(err, app.rollbackStart(ctx, listener))
+	}
+	return nil
+}
+
+// rollbackStart shuts down the http server and stops the worker pools started by [Application.Start]
+func (app *Application) rollbackStart(ctx context.Context, listener net.Listener) error {
+	var err error
+	if app.router != nil {
+		err = app.router.Shutdown(ctx)
+	}
+	if app.pools != nil {
+		for name := range app.pools.List() {
+			app.pools.Stop(name)
+		}
+	}
+	app.mu.Lock()
+	app.listener = nil
+	app.serveErr = nil
+	app.mu.Unlock()
+	app.abortStart(listener)
+	return err
+}
+
+// abortStart closes the listener and marks the application as not started, so that it can be started again
+func (app *Application) abortStart(listener net.Listener) {
+	if listener != nil {
+		_ = listener.Close()
+	}
+	app.mu.Lock()
+	app.started = false
+	app.mu.Unlock()
+}
+
+// Run starts the application and blocks until SIGINT or SIGTERM is received, then shuts it down gracefully
+func (app *Application) Run() error {
+	return app.RunContext(context.Background())
+}
+
+// RunContext is like [Application.Run], the application is shut down when ctx is done as well
+func (app *Application) RunContext(ctx context.Context) error {
+	if err := app.Start(ctx); err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(ctx, app.signals...)
+	defer stop()
+	var serveErr error
+	select {
+	case <-ctx.Done():
+	case serveErr = <-app.serveErrors():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), app.shutdownTimeout)
+	defer cancel()
+	return errors.Join(serveErr, app.Shutdown(shutdownCtx))
+}
+
+// serveErrors returns the channel receiving the error of the http server, it's nil if the server isn't started
+func (app *Application) serveErrors() <-chan error {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	return app.serveErr
+}
+
+// Shutdown shuts the application down gracefully, in the order of:
+//
+//  1. stopping hooks
+//  2. the http server, in-flight requests are drained
+//  3. the worker pools
+//  4. providers implementing [Shutdowner], in the reverse order of registration
+//  5. stopped hooks
+//
+// all steps are executed, errors are joined.
+func (app *Application) Shutdown(ctx context.Context) error {
+	app.mu.Lock()
+	if !app.started {
+		app.mu.Unlock()
+		return ErrNotStarted
+	}
+	app.started = false
+	app.mu.Unlock()
+
+	errs := []error{app.fire(ctx, Stopping)}
+	if app.router != nil {
+		errs = append(errs, app.router.Shutdown(ctx))
+	}
+	if app.pools != nil {
+		for name := range app.pools.List() {
+			app.pools.Stop(name)
+		}
+	}
+	providers := app.container.Providers()
+	for i := len(providers) - 1; i >= 0; i-- {
+		if shutdowner, ok := providers[i].(Shutdowner); ok {
+			errs = append(errs, shutdowner.Shutdown(ctx))
+		}
+	}
+	errs = append(errs, app.fire(ctx, Stopped))
+	return errors.Join(errs...)
+}
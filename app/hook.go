@@ -0,0 +1,80 @@
+package app
+
+import "context"
+
+// Stage lifecycle stage of the application
+type Stage int
+
+const (
+	// Booting before providers are booted
+	Booting Stage = iota
+	// Booted after providers are booted
+	Booted
+	// Starting before the http server and worker pools are started
+	Starting
+	// Started after the http server and worker pools are started
+	Started
+	// Stopping before the http server and worker pools are stopped
+	Stopping
+	// Stopped after the http server, worker pools and providers are stopped
+	Stopped
+)
+
+// Hook lifecycle hook, an error of hooks aborts booting and starting
+type Hook func(ctx context.Context, app *Application) error
+
+// Shutdowner providers implement it to release resources on shutdown, e.g. closing database connections
+//
+// providers are shut down in the reverse order of registration.
+type Shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// OnBooting adds hooks running before providers are booted
+func (app *Application) OnBooting(hooks ...Hook) *Application {
+	return app.hook(Booting, hooks...)
+}
+
+// OnBooted adds hooks running after providers are booted
+func (app *Application) OnBooted(hooks ...Hook) *Application {
+	return app.hook(Booted, hooks...)
+}
+
+// OnStarting adds hooks running before the http server and worker pools are started
+func (app *Application) OnStarting(hooks ...Hook) *Application {
+	return app.hook(Starting, hooks...)
+}
+
+// OnStarted adds hooks running after the http server and worker pools are started
+func (app *Application) OnStarted(hooks ...Hook) *Application {
+	return app.hook(Started, hooks...)
+}
+
+// OnStopping adds hooks running before the http server and worker pools are stopped
+func (app *Application) OnStopping(hooks ...Hook) *Application {
+	return app.hook(Stopping, hooks...)
+}
+
+// OnStopped adds hooks running after the http server, worker pools and providers are stopped
+func (app *Application) OnStopped(hooks ...Hook) *Application {
+	return app.hook(Stopped, hooks...)
+}
+
+func (app *Application) hook(stage Stage, hooks ...Hook) *Application {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	app.hooks[stage] = append(app.hooks[stage], hooks...)
+	return app
+}
+
+func (app *Application) fire(ctx context.Context, stage Stage) error {
+	app.mu.Lock()
+	hooks := append([]Hook(nil), app.hooks[stage]...)
+	app.mu.Unlock()
+	for _, hook := range hooks {
+		if err := hook(ctx, app); err != nil {
+			return err
+		}
+	}
+	return nil
+}
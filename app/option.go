@@ -0,0 +1,55 @@
+package app
+
+import (
+	"os"
+	"time"
+
+	"github.com/wardonne/gopi/container"
+	"github.com/wardonne/gopi/web/router"
+	"github.com/wardonne/gopi/workerpool"
+)
+
+// Option application option fn
+type Option func(*Application)
+
+// WithContainer sets the dependency injection container, a new one is created by default
+func WithContainer(c *container.Container) Option {
+	return func(app *Application) {
+		app.container = c
+	}
+}
+
+// WithRouter sets the router, the http server is not started if the router is nil
+func WithRouter(r *router.Router) Option {
+	return func(app *Application) {
+		app.router = r
+	}
+}
+
+// WithAddr sets the listening address of the http server, default is ":8080"
+func WithAddr(addr string) Option {
+	return func(app *Application) {
+		app.addr = addr
+	}
+}
+
+// WithPools sets the worker pool manager, pools are started and stopped with the application
+func WithPools(pools *workerpool.Manager) Option {
+	return func(app *Application) {
+		app.pools = pools
+	}
+}
+
+// WithShutdownTimeout sets the timeout of graceful shutdown, default is 30 seconds
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(app *Application) {
+		app.shutdownTimeout = timeout
+	}
+}
+
+// WithSignals sets the signals which trigger graceful shutdown, default are SIGINT and SIGTERM
+func WithSignals(signals ...os.Signal) Option {
+	return func(app *Application) {
+		app.signals = signals
+	}
+}
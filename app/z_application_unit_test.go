@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/container"
+	webctx "github.com/wardonne/gopi/web/context"
+	"github.com/wardonne/gopi/web/router"
+	"github.com/wardonne/gopi/workerpool"
+	"github.com/wardonne/gopi/workerpool/driver"
+)
+
+type provider struct {
+	name   string
+	events *[]string
+}
+
+func (p *provider) Register(c *container.Container) error {
+	*p.events = append(*p.events, "register "+p.name)
+	return nil
+}
+
+func (p *provider) Boot(c *container.Container) error {
+	*p.events = append(*p.events, "boot "+p.name)
+	return nil
+}
+
+func (p *provider) Shutdown(ctx context.Context) error {
+	*p.events = append(*p.events, "shutdown "+p.name)
+	return nil
+}
+
+func record(events *[]string, event string) Hook {
+	return func(ctx context.Context, app *Application) error {
+		*events = append(*events, event)
+		return nil
+	}
+}
+
+func newApplication(events *[]string) *Application {
+	r := router.New()
+	r.GET("/", func(request *webctx.Request) webctx.IResponse {
+		return webctx.NewResponse(200, "ok")
+	})
+	application := New(WithRouter(r), WithAddr("127.0.0.1:0"), WithSignals(syscall.SIGUSR1))
+	application.
+		OnBooting(record(events, "booting")).
+		OnBooted(record(events, "booted")).
+		OnStarting(record(events, "starting")).
+		OnStarted(record(events, "started")).
+		OnStopping(record(events, "stopping")).
+		OnStopped(record(events, "stopped"))
+	return application
+}
+
+func get(t *testing.T, application *Application) string {
+	resp, err := http.Get("http://" + application.Addr().String() + "/")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestApplication_Lifecycle(t *testing.T) {
+	events := make([]string, 0)
+	application := newApplication(&events)
+	assert.NoError(t, application.Register(&provider{name: "a", events: &events}, &provider{name: "b", events: &events}))
+
+	assert.NoError(t, application.Start(context.Background()))
+	assert.ErrorIs(t, application.Start(context.Background()), ErrAlreadyStarted)
+	assert.Equal(t, "ok", get(t, application))
+	assert.Same(t, application, container.MustResolve[*Application](application.Container()))
+	assert.Same(t, application.Container(), application.Router().Container())
+
+	assert.NoError(t, application.Shutdown(context.Background()))
+	assert.ErrorIs(t, application.Shutdown(context.Background()), ErrNotStarted)
+	assert.Equal(t, []string{
+		"register a", "register b",
+		"booting", "boot a", "boot b", "booted",
+		"starting", "started",
+		"stopping", "shutdown b", "shutdown a", "stopped",
+	}, events)
+}
+
+func TestApplication_Boot(t *testing.T) {
+	expected := errors.New("failed")
+	application := New()
+	application.OnBooting(func(ctx context.Context, app *Application) error {
+		return expected
+	})
+	assert.ErrorIs(t, application.Start(context.Background()), expected)
+	assert.ErrorIs(t, application.Boot(context.Background()), expected)
+}
+
+func TestApplication_Run(t *testing.T) {
+	t.Run("shutdown on signal", func(t *testing.T) {
+		events := make([]string, 0)
+		application := newApplication(&events)
+		application.OnStarted(func(ctx context.Context, app *Application) error {
+			go func() {
+				time.Sleep(10 * time.Millisecond)
+				_ = syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+			}()
+			return nil
+		})
+		assert.NoError(t, application.Run())
+		assert.Contains(t, events, "stopped")
+	})
+
+	t.Run("shutdown on context done", func(t *testing.T) {
+		events := make([]string, 0)
+		application := newApplication(&events)
+		ctx, cancel := context.WithCancel(context.Background())
+		application.OnStarted(func(_ context.Context, app *Application) error {
+			cancel()
+			return nil
+		})
+		assert.NoError(t, application.RunContext(ctx))
+		assert.Equal(t, "stopped", events[len(events)-1])
+	})
+
+	t.Run("listen error", func(t *testing.T) {
+		application := New(WithRouter(router.New()), WithAddr("invalid address"))
+		assert.Error(t, application.RunContext(context.Background()))
+	})
+
+	t.Run("occupied address", func(t *testing.T) {
+		occupied, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		pools := workerpool.NewManager()
+		pools.Create("default", driver.NewMemoryDriver())
+		events := make([]string, 0)
+		application := New(WithRouter(router.New()), WithPools(pools), WithAddr(occupied.Addr().String()))
+		application.OnStarting(record(&events, "starting"))
+
+		assert.Error(t, application.Start(context.Background()))
+		assert.Empty(t, events)
+		assert.False(t, pools.Get("default").IsRunning())
+		assert.ErrorIs(t, application.Shutdown(context.Background()), ErrNotStarted)
+
+		assert.NoError(t, occupied.Close())
+		assert.NoError(t, application.Start(context.Background()))
+		assert.True(t, pools.Get("default").IsRunning())
+		assert.NoError(t, application.Shutdown(context.Background()))
+	})
+
+	t.Run("started hook error", func(t *testing.T) {
+		expected := errors.New("failed")
+		pools := workerpool.NewManager()
+		pools.Create("default", driver.NewMemoryDriver())
+		application := New(WithRouter(router.New()), WithPools(pools), WithAddr("127.0.0.1:0"))
+		var addr string
+		application.OnStarted(func(ctx context.Context, app *Application) error {
+			addr = app.Addr().String()
+			return expected
+		})
+
+		assert.ErrorIs(t, application.RunContext(context.Background()), expected)
+		assert.Nil(t, application.Addr())
+		assert.False(t, pools.Get("default").IsRunning())
+		assert.ErrorIs(t, application.Shutdown(context.Background()), ErrNotStarted)
+		_, err := net.DialTimeout("tcp", addr, time.Second)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,32 @@
+package config
+
+import "github.com/wardonne/gopi/container"
+
+// Provider registers the [Configuration] into the container and loads config files on booting
+type Provider struct {
+	configuration *Configuration
+	names         []string
+}
+
+// NewProvider creates a new [Provider] instance
+//
+//	c.Provide(config.NewProvider(config.New(), "app", "database"))
+func NewProvider(configuration *Configuration, names ...string) *Provider {
+	return &Provider{configuration: configuration, names: names}
+}
+
+// Register registers the [Configuration] as a singleton
+func (p *Provider) Register(c *container.Container) error {
+	container.Instance(c, p.configuration)
+	return nil
+}
+
+// Boot loads the config files
+func (p *Provider) Boot(c *container.Container) error {
+	for _, name := range p.names {
+		if err := p.configuration.Load(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
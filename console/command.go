@@ -0,0 +1,53 @@
+package console
+
+// Command console command
+//
+//	type GreetCommand struct{}
+//
+//	func (*GreetCommand) Signature() string {
+//		return "greet {name : The name to greet} {--Y|yell : Yell the greeting}"
+//	}
+//
+//	func (*GreetCommand) Description() string {
+//		return "Greet someone"
+//	}
+//
+//	func (*GreetCommand) Handle(ctx *console.Context) error {
+//		ctx.Line("Hello %s", ctx.Argument("name"))
+//		return nil
+//	}
+type Command interface {
+	// Signature returns the name, arguments and options of the command, see [Parse]
+	Signature() string
+	// Description returns the description shown in help
+	Description() string
+	// Handle handles the command
+	Handle(ctx *Context) error
+}
+
+type command struct {
+	signature   string
+	description string
+	handle      func(ctx *Context) error
+}
+
+// NewCommand creates a [Command] with the handle function
+//
+//	console.NewCommand("cache:clear", "Flush the cache", func(ctx *console.Context) error {
+//		return cache.Flush()
+//	})
+func NewCommand(signature, description string, handle func(ctx *Context) error) Command {
+	return &command{signature: signature, description: description, handle: handle}
+}
+
+func (c *command) Signature() string {
+	return c.signature
+}
+
+func (c *command) Description() string {
+	return c.description
+}
+
+func (c *command) Handle(ctx *Context) error {
+	return c.handle(ctx)
+}
@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/wardonne/gopi/console"
+	"github.com/wardonne/gopi/database/migration"
+)
+
+// Migrate commits the pending migrations in order, applied migrations are recorded in the repository
+//
+//	commands.NewMigrate(migration.NewDatabaseRepository(db, ""), new(CreateUsersTable), new(CreatePostsTable))
+type Migrate struct {
+	repository migration.Repository
+	migrators  []migration.Migrator
+}
+
+// NewMigrate creates a new [Migrate] instance, it panics if the names of migrators are duplicated
+func NewMigrate(repository migration.Repository, migrators ...migration.Migrator) *Migrate {
+	mustUniqueNames(migrators)
+	return &Migrate{repository: repository, migrators: migrators}
+}
+
+// Signature returns the signature of the command
+func (command *Migrate) Signature() string {
+	return `migrate {--force : Run the migrations without confirmation}`
+}
+
+// Description returns the description of the command
+func (command *Migrate) Description() string {
+	return "Run the pending database migrations"
+}
+
+// Handle commits the pending migrations, it stops at the first failed migration
+func (command *Migrate) Handle(ctx *console.Context) error {
+	if err := command.repository.Prepare(); err != nil {
+		return err
+	}
+	ran, err := command.repository.Ran()
+	if err != nil {
+		return err
+	}
+	applied := make(map[string]bool, len(ran))
+	for _, name := range ran {
+		applied[name] = true
+	}
+	pending := make([]migration.Migrator, 0, len(command.migrators))
+	for _, migrator := range command.migrators {
+		if !applied[migration.Name(migrator)] {
+			pending = append(pending, migrator)
+		}
+	}
+	if len(pending) == 0 {
+		ctx.Line("Nothing to migrate.")
+		return nil
+	}
+	if !ctx.BoolOption("force") && !ctx.Confirm(fmt.Sprintf("Do you really wish to run %d migrations?", len(pending)), true) {
+		ctx.Line("Command canceled.")
+		return nil
+	}
+	for _, migrator := range pending {
+		if err := migrate(ctx, "Migrating", migrator, migrator.Commit); err != nil {
+			return err
+		}
+		if err := command.repository.Log(migration.Name(migrator)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MigrateRollback rolls back the applied migrations in the reverse order they were applied
+//
+//	commands.NewMigrateRollback(migration.NewDatabaseRepository(db, ""), new(CreateUsersTable), new(CreatePostsTable))
+type MigrateRollback struct {
+	repository migration.Repository
+	migrators  map[string]migration.Migrator
+}
+
+// NewMigrateRollback creates a new [MigrateRollback] instance, it panics if the names of migrators are duplicated
+func NewMigrateRollback(repository migration.Repository, migrators ...migration.Migrator) *MigrateRollback {
+	mustUniqueNames(migrators)
+	command := &MigrateRollback{repository: repository, migrators: make(map[string]migration.Migrator, len(migrators))}
+	for _, migrator := range migrators {
+		command.migrators[migration.Name(migrator)] = migrator
+	}
+	return command
+}
+
+// Signature returns the signature of the command
+func (command *MigrateRollback) Signature() string {
+	return `migrate:rollback
+		{--step=1 : The number of migrations to be rolled back, 0 rolls back all}
+		{--force : Roll back the migrations without confirmation}`
+}
+
+// Description returns the description of the command
+func (command *MigrateRollback) Description() string {
+	return "Rollback the last applied database migrations"
+}
+
+// Handle rolls back the last applied migrations, it fails if any of them isn't given to [NewMigrateRollback]
+func (command *MigrateRollback) Handle(ctx *console.Context) error {
+	step, err := strconv.Atoi(ctx.Option("step"))
+	if err != nil || step < 0 {
+		return fmt.Errorf("invalid step %q", ctx.Option("step"))
+	}
+	if err := command.repository.Prepare(); err != nil {
+		return err
+	}
+	ran, err := command.repository.Ran()
+	if err != nil {
+		return err
+	}
+	if step == 0 || step > len(ran) {
+		step = len(ran)
+	}
+	if step == 0 {
+		ctx.Line("Nothing to roll back.")
+		return nil
+	}
+	migrators := make([]migration.Migrator, 0, step)
+	for i := len(ran) - 1; i >= len(ran)-step; i-- {
+		migrator, ok := command.migrators[ran[i]]
+		if !ok {
+			return fmt.Errorf("migration %s is applied but not registered", ran[i])
+		}
+		migrators = append(migrators, migrator)
+	}
+	if !ctx.BoolOption("force") && !ctx.Confirm(fmt.Sprintf("Do you really wish to roll back %d migrations?", step), false) {
+		ctx.Line("Command canceled.")
+		return nil
+	}
+	for _, migrator := range migrators {
+		if err := migrate(ctx, "Rolling back", migrator, migrator.Rollback); err != nil {
+			return err
+		}
+		if err := command.repository.Delete(migration.Name(migrator)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mustUniqueNames(migrators []migration.Migrator) {
+	names := make(map[string]bool, len(migrators))
+	for _, migrator := range migrators {
+		name := migration.Name(migrator)
+		if names[name] {
+			panic(fmt.Errorf("migration %s is duplicated", name))
+		}
+		names[name] = true
+	}
+}
+
+// migrate runs the step of the migrator, database errors are thrown as panics so they're recovered as errors
+func migrate(ctx *console.Context, action string, migrator migration.Migrator, step func()) (err error) {
+	ctx.Line("%s: %T", action, migrator)
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%s %T: %v", action, migrator, recovered)
+		}
+	}()
+	step()
+	ctx.Line("Done: %T", migrator)
+	return nil
+}
@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/wardonne/gopi/console"
+	"github.com/wardonne/gopi/workerpool"
+	"github.com/wardonne/gopi/workerpool/job"
+)
+
+// FailedJobs drivers implement it to list failed jobs, e.g. the memory driver
+type FailedJobs interface {
+	Failed() []job.Interface
+}
+
+// QueueWork starts worker pools and stops them when the command is interrupted
+type QueueWork struct {
+	pools    *workerpool.Manager
+	interval time.Duration
+}
+
+// NewQueueWork creates a new [QueueWork] instance
+func NewQueueWork(pools *workerpool.Manager) *QueueWork {
+	return &QueueWork{pools: pools, interval: time.Second}
+}
+
+// Signature returns the signature of the command
+func (command *QueueWork) Signature() string {
+	return `queue:work
+		{pools?* : The worker pools to start, all pools are started if omitted}
+		{--stop-when-empty : Stop the worker pools when their queues are empty}`
+}
+
+// Description returns the description of the command
+func (command *QueueWork) Description() string {
+	return "Start processing jobs on the worker pools"
+}
+
+// Handle starts the worker pools and blocks until the command is interrupted
+func (command *QueueWork) Handle(ctx *console.Context) error {
+	names, err := poolNames(command.pools, ctx.Arguments("pools"))
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		command.pools.Start(name)
+		ctx.Line("Worker pool [%s] started.", name)
+	}
+	defer func() {
+		for _, name := range names {
+			command.pools.Stop(name)
+			ctx.Line("Worker pool [%s] stopped.", name)
+		}
+	}()
+	ticker := time.NewTicker(command.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if ctx.BoolOption("stop-when-empty") && command.empty(names) {
+				return nil
+			}
+		}
+	}
+}
+
+func (command *QueueWork) empty(names []string) bool {
+	for _, name := range names {
+		if command.pools.Get(name).Driver().Count() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// QueueFailed lists the failed jobs of worker pools
+type QueueFailed struct {
+	pools *workerpool.Manager
+}
+
+// NewQueueFailed creates a new [QueueFailed] instance
+func NewQueueFailed(pools *workerpool.Manager) *QueueFailed {
+	return &QueueFailed{pools: pools}
+}
+
+// Signature returns the signature of the command
+func (command *QueueFailed) Signature() string {
+	return `queue:failed {pools?* : The worker pools to list, all pools are listed if omitted}`
+}
+
+// Description returns the description of the command
+func (command *QueueFailed) Description() string {
+	return "List all of the failed jobs"
+}
+
+// Handle lists the failed jobs, pools whose drivers don't implement [FailedJobs] are skipped
+func (command *QueueFailed) Handle(ctx *console.Context) error {
+	names, err := poolNames(command.pools, ctx.Arguments("pools"))
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0)
+	for _, name := range names {
+		driver, ok := command.pools.Get(name).Driver().(FailedJobs)
+		if !ok {
+			ctx.Error("Driver of worker pool [%s] can't list failed jobs.", name)
+			continue
+		}
+		for index, failed := range driver.Failed() {
+			payload, err := failed.MarshalJSON()
+			if err != nil {
+				return err
+			}
+			rows = append(rows, []string{name, fmt.Sprint(index), fmt.Sprintf("%T", failed), string(payload)})
+		}
+	}
+	if len(rows) == 0 {
+		ctx.Line("No failed jobs found.")
+		return nil
+	}
+	ctx.Table([]string{"POOL", "#", "JOB", "PAYLOAD"}, rows)
+	return nil
+}
+
+// QueueRetry pushes the failed jobs of worker pools back into their queues
+type QueueRetry struct {
+	pools *workerpool.Manager
+}
+
+// NewQueueRetry creates a new [QueueRetry] instance
+func NewQueueRetry(pools *workerpool.Manager) *QueueRetry {
+	return &QueueRetry{pools: pools}
+}
+
+// Signature returns the signature of the command
+func (command *QueueRetry) Signature() string {
+	return `queue:retry {pools?* : The worker pools to retry, all pools are retried if omitted}`
+}
+
+// Description returns the description of the command
+func (command *QueueRetry) Description() string {
+	return "Retry the failed jobs"
+}
+
+// Handle reloads the failed jobs of the worker pools
+func (command *QueueRetry) Handle(ctx *console.Context) error {
+	names, err := poolNames(command.pools, ctx.Arguments("pools"))
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		command.pools.Get(name).Driver().Reload()
+		ctx.Line("Failed jobs of worker pool [%s] are pushed back into the queue.", name)
+	}
+	return nil
+}
+
+// poolNames returns the names if all of them are registered, or the names of all pools if it's empty
+func poolNames(pools *workerpool.Manager, names []string) ([]string, error) {
+	if len(names) == 0 {
+		for name := range pools.List() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names, nil
+	}
+	for _, name := range names {
+		if pools.Get(name) == nil {
+			return nil, fmt.Errorf("worker pool [%s] is not registered", name)
+		}
+	}
+	return names, nil
+}
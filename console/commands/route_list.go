@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wardonne/gopi/console"
+	"github.com/wardonne/gopi/web/router"
+)
+
+// RouteList lists the routes of the router
+type RouteList struct {
+	router *router.Router
+}
+
+// NewRouteList creates a new [RouteList] instance
+func NewRouteList(r *router.Router) *RouteList {
+	return &RouteList{router: r}
+}
+
+// Signature returns the signature of the command
+func (command *RouteList) Signature() string {
+	return `route:list
+		{--method= : Filter the routes by method}
+		{--path= : Filter the routes by path}
+		{--name= : Filter the routes by name}`
+}
+
+// Description returns the description of the command
+func (command *RouteList) Description() string {
+	return "List all registered routes"
+}
+
+// Handle lists the routes sorted by path and method
+func (command *RouteList) Handle(ctx *console.Context) error {
+	method := strings.ToUpper(ctx.Option("method"))
+	path, name := ctx.Option("path"), ctx.Option("name")
+	routes := make([]router.IRoute, 0)
+	for _, route := range command.router.List() {
+		if method != "" && route.Method() != method ||
+			path != "" && !strings.Contains(route.Path(), path) ||
+			name != "" && !strings.Contains(route.Name(), name) {
+			continue
+		}
+		routes = append(routes, route)
+	}
+	if len(routes) == 0 {
+		ctx.Line("No routes matched.")
+		return nil
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Path() != routes[j].Path() {
+			return routes[i].Path() < routes[j].Path()
+		}
+		return routes[i].Method() < routes[j].Method()
+	})
+	rows := make([][]string, 0, len(routes))
+	for _, route := range routes {
+		rows = append(rows, []string{
+			route.Method(), route.Path(), route.Name(), route.Handler(), strconv.Itoa(len(route.Middlewares())),
+		})
+	}
+	ctx.Table([]string{"METHOD", "PATH", "NAME", "HANDLER", "MIDDLEWARES"}, rows)
+	return nil
+}
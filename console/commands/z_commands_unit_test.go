@@ -0,0 +1,240 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/console"
+	"github.com/wardonne/gopi/database/migration"
+	webctx "github.com/wardonne/gopi/web/context"
+	"github.com/wardonne/gopi/web/router"
+	"github.com/wardonne/gopi/workerpool"
+	"github.com/wardonne/gopi/workerpool/driver"
+	"github.com/wardonne/gopi/workerpool/job"
+)
+
+func run(t *testing.T, command console.Command, input string, args ...string) (string, error) {
+	out := new(bytes.Buffer)
+	c := console.New("app", "1.0.0").Register(command)
+	c.In, c.Out, c.Err = strings.NewReader(input), out, out
+	err := c.Run(context.Background(), append([]string{console.Parse(command.Signature()).Name}, args...))
+	return out.String(), err
+}
+
+func TestRouteList(t *testing.T) {
+	handler := func(request *webctx.Request) webctx.IResponse {
+		return webctx.NewResponse(200, "")
+	}
+	r := router.New()
+	r.GET("/users", handler).AS("users.index")
+	r.POST("/users", handler).AS("users.store")
+	r.GET("/posts", handler)
+
+	output, err := run(t, NewRouteList(r), "")
+	assert.NoError(t, err)
+	assert.Regexp(t, `(?s)METHOD\s+PATH\s+NAME\s+HANDLER\s+MIDDLEWARES\n`+
+		`GET\s+/posts\s+\S+\s+0\n`+
+		`GET\s+/users\s+users.index\s+\S+\s+0\n`+
+		`POST\s+/users\s+users.store\s+\S+\s+0\n$`, output)
+
+	output, err = run(t, NewRouteList(r), "", "--method=post")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "users.store")
+	assert.NotContains(t, output, "users.index")
+
+	output, err = run(t, NewRouteList(r), "", "--name=posts")
+	assert.NoError(t, err)
+	assert.Equal(t, "No routes matched.\n", output)
+}
+
+type testjob struct {
+	job.Job
+	payload string
+}
+
+func (j *testjob) MarshalJSON() ([]byte, error) {
+	return []byte(j.payload), nil
+}
+
+func (j *testjob) UnmarshalJSON(data []byte) error {
+	return nil
+}
+
+func (j *testjob) Handle() error {
+	return nil
+}
+
+func TestQueue(t *testing.T) {
+	pools := workerpool.NewManager()
+	failed := driver.NewMemoryDriver()
+	failed.Fail(&testjob{payload: `{"id":1}`})
+	pools.Create("emails", failed)
+	pools.Create("reports", driver.NewMemoryDriver())
+
+	output, err := run(t, NewQueueFailed(pools), "")
+	assert.NoError(t, err)
+	assert.Regexp(t, `POOL\s+#\s+JOB\s+PAYLOAD\nemails\s+0\s+\*commands.testjob\s+\{"id":1\}\n`, output)
+
+	_, err = run(t, NewQueueFailed(pools), "", "unknown")
+	assert.EqualError(t, err, "worker pool [unknown] is not registered")
+
+	output, err = run(t, NewQueueRetry(pools), "", "emails")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "[emails]")
+	assert.NotContains(t, output, "[reports]")
+	assert.Empty(t, failed.Failed())
+	assert.Equal(t, int64(1), failed.Count())
+
+	output, err = run(t, NewQueueFailed(pools), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "No failed jobs found.\n", output)
+}
+
+func TestQueueWork(t *testing.T) {
+	pools := workerpool.NewManager()
+	pools.Create("emails", driver.NewMemoryDriver())
+	command := NewQueueWork(pools)
+	command.interval = 10 * time.Millisecond
+
+	output, err := run(t, command, "", "emails", "--stop-when-empty")
+	assert.NoError(t, err)
+	assert.Equal(t, "Worker pool [emails] started.\nWorker pool [emails] stopped.\n", output)
+	assert.True(t, pools.Get("emails").IsStopped())
+}
+
+type migrator struct {
+	name   string
+	events *[]string
+	err    error
+}
+
+func (m *migrator) Name() string {
+	return m.name
+}
+
+func (m *migrator) Commit() {
+	if m.err != nil {
+		panic(m.err)
+	}
+	*m.events = append(*m.events, "commit "+m.name)
+}
+
+func (m *migrator) Rollback() {
+	*m.events = append(*m.events, "rollback "+m.name)
+}
+
+type repository struct {
+	ran []string
+}
+
+func (r *repository) Prepare() error {
+	return nil
+}
+
+func (r *repository) Ran() ([]string, error) {
+	return append([]string(nil), r.ran...), nil
+}
+
+func (r *repository) Log(name string) error {
+	r.ran = append(r.ran, name)
+	return nil
+}
+
+func (r *repository) Delete(name string) error {
+	for i, ran := range r.ran {
+		if ran == name {
+			r.ran = append(r.ran[:i], r.ran[i+1:]...)
+		}
+	}
+	return nil
+}
+
+func TestMigrate(t *testing.T) {
+	t.Run("migrate", func(t *testing.T) {
+		events := make([]string, 0)
+		repository := &repository{ran: []string{"a"}}
+		migrators := []migration.Migrator{&migrator{name: "a", events: &events}, &migrator{name: "b", events: &events}, &migrator{name: "c", events: &events}}
+		output, err := run(t, NewMigrate(repository, migrators...), "", "--force")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"commit b", "commit c"}, events)
+		assert.Equal(t, []string{"a", "b", "c"}, repository.ran)
+		assert.Contains(t, output, "Migrating: *commands.migrator")
+
+		output, err = run(t, NewMigrate(repository, migrators...), "", "--force")
+		assert.NoError(t, err)
+		assert.Equal(t, "Nothing to migrate.\n", output)
+		assert.Len(t, events, 2)
+	})
+
+	t.Run("migrate fails", func(t *testing.T) {
+		events := make([]string, 0)
+		repository := new(repository)
+		_, err := run(t, NewMigrate(
+			repository,
+			&migrator{name: "a", events: &events},
+			&migrator{name: "b", events: &events, err: errors.New("boom")},
+			&migrator{name: "c", events: &events},
+		), "yes\n")
+		assert.EqualError(t, err, "Migrating *commands.migrator: boom")
+		assert.Equal(t, []string{"commit a"}, events)
+		assert.Equal(t, []string{"a"}, repository.ran)
+	})
+
+	t.Run("duplicated names", func(t *testing.T) {
+		assert.PanicsWithError(t, "migration a is duplicated", func() {
+			NewMigrate(new(repository), &migrator{name: "a"}, &migrator{name: "a"})
+		})
+		assert.PanicsWithError(t, "migration a is duplicated", func() {
+			NewMigrateRollback(new(repository), &migrator{name: "a"}, &migrator{name: "a"})
+		})
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		events := make([]string, 0)
+		migrators := []migration.Migrator{
+			&migrator{name: "a", events: &events},
+			&migrator{name: "b", events: &events},
+			&migrator{name: "c", events: &events},
+		}
+		repository := &repository{ran: []string{"a", "c", "b"}}
+		_, err := run(t, NewMigrateRollback(repository, migrators...), "", "--force")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"rollback b"}, events)
+		assert.Equal(t, []string{"a", "c"}, repository.ran)
+
+		events = events[:0]
+		output, err := run(t, NewMigrateRollback(repository, migrators...), "\n", "--step=2")
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+		assert.Contains(t, output, "Command canceled.")
+
+		_, err = run(t, NewMigrateRollback(repository, migrators...), "y\n", "--step=0")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"rollback c", "rollback a"}, events)
+		assert.Empty(t, repository.ran)
+
+		// rolled back migrations are never rolled back again
+		events = events[:0]
+		output, err = run(t, NewMigrateRollback(repository, migrators...), "", "--force")
+		assert.NoError(t, err)
+		assert.Equal(t, "Nothing to roll back.\n", output)
+		assert.Empty(t, events)
+
+		_, err = run(t, NewMigrateRollback(repository, migrators...), "", "--step=x")
+		assert.Error(t, err)
+	})
+
+	t.Run("rollback unregistered migration", func(t *testing.T) {
+		events := make([]string, 0)
+		repository := &repository{ran: []string{"a", "removed"}}
+		_, err := run(t, NewMigrateRollback(repository, &migrator{name: "a", events: &events}), "", "--force", "--step=2")
+		assert.EqualError(t, err, "migration removed is applied but not registered")
+		assert.Empty(t, events)
+		assert.Equal(t, []string{"a", "removed"}, repository.ran)
+	})
+}
@@ -0,0 +1,212 @@
+package console
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/pflag"
+)
+
+var (
+	// ErrCommandNotFound no command is registered with the name
+	ErrCommandNotFound = errors.New("command not found")
+	// ErrMissingArgument a required argument is not passed
+	ErrMissingArgument = errors.New("not enough arguments")
+	// ErrTooManyArguments more arguments than declared are passed
+	ErrTooManyArguments = errors.New("too many arguments")
+	// ErrOptionNotDefined the option is not declared in the signature
+	ErrOptionNotDefined = errors.New("option is not defined")
+)
+
+type entry struct {
+	command    Command
+	definition *Definition
+}
+
+// Console command registry and runner
+//
+//	c := console.New("app", "1.0.0")
+//	c.Register(commands.NewMigrate(migration.NewDatabaseRepository(db, ""), migrators...), commands.NewRouteList(router))
+//	os.Exit(c.Execute())
+type Console struct {
+	name     string
+	version  string
+	commands map[string]*entry
+
+	In  io.Reader
+	Out io.Writer
+	Err io.Writer
+}
+
+// New creates a new [Console] instance with the built-in list and help commands
+func New(name, version string) *Console {
+	console := &Console{
+		name:     name,
+		version:  version,
+		commands: make(map[string]*entry),
+		In:       os.Stdin,
+		Out:      os.Stdout,
+		Err:      os.Stderr,
+	}
+	console.Register(
+		NewCommand("list {namespace? : Only list commands of the namespace}", "List commands", console.list),
+		NewCommand("help {command_name=help : The command name}", "Display help for a command", console.help),
+	)
+	return console
+}
+
+// Register registers commands, commands with the same name are replaced
+//
+// it panics if the signature is invalid, or uses the reserved options --help (-h) and --no-interaction (-n).
+func (console *Console) Register(commands ...Command) *Console {
+	for _, command := range commands {
+		definition := Parse(command.Signature())
+		for _, option := range definition.Options {
+			if option.Name == "help" || option.Name == "no-interaction" {
+				panic(fmt.Errorf("option --%s of %s is reserved", option.Name, definition.Name))
+			}
+			if option.Shortcut == "h" || option.Shortcut == "n" {
+				panic(fmt.Errorf("shortcut -%s of %s is reserved", option.Shortcut, definition.Name))
+			}
+		}
+		console.commands[definition.Name] = &entry{command: command, definition: definition}
+	}
+	return console
+}
+
+// Find returns the command by name
+func (console *Console) Find(name string) (Command, bool) {
+	entry, ok := console.commands[name]
+	if !ok {
+		return nil, false
+	}
+	return entry.command, true
+}
+
+// Commands returns the registered commands sorted by name
+func (console *Console) Commands() []Command {
+	names := console.names()
+	commands := make([]Command, 0, len(names))
+	for _, name := range names {
+		commands = append(commands, console.commands[name].command)
+	}
+	return commands
+}
+
+// Execute runs the command of os.Args and returns the exit code,
+// the context of the command is canceled on SIGINT or SIGTERM
+//
+//	func main() {
+//		os.Exit(c.Execute())
+//	}
+func (console *Console) Execute() int {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := console.Run(ctx, os.Args[1:]); err != nil {
+		fmt.Fprintln(console.Err, err)
+		return 1
+	}
+	return 0
+}
+
+// Run runs the command with args, the first arg is the command name
+//
+// the commands are listed if args are empty.
+func (console *Console) Run(ctx context.Context, args []string) error {
+	return console.run(ctx, args, true)
+}
+
+func (console *Console) run(ctx context.Context, args []string, interactive bool) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" {
+		args = []string{"list"}
+	}
+	if args[0] == "-V" || args[0] == "--version" {
+		fmt.Fprintf(console.Out, "%s %s\n", console.name, console.version)
+		return nil
+	}
+	entry, ok := console.commands[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrCommandNotFound, args[0])
+	}
+
+	flags := pflag.NewFlagSet(entry.definition.Name, pflag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	for _, option := range entry.definition.Options {
+		switch option.Kind {
+		case OptionBool:
+			flags.BoolP(option.Name, option.Shortcut, false, option.Description)
+		case OptionArray:
+			flags.StringArrayP(option.Name, option.Shortcut, nil, option.Description)
+		default:
+			flags.StringP(option.Name, option.Shortcut, option.Default, option.Description)
+		}
+	}
+	help := flags.BoolP("help", "h", false, "Display help for the command")
+	noInteraction := flags.BoolP("no-interaction", "n", false, "Do not ask any interactive question")
+	if err := flags.Parse(args[1:]); err != nil {
+		return fmt.Errorf("%s: %w", entry.definition.Name, err)
+	}
+	if *help {
+		return console.run(ctx, []string{"help", entry.definition.Name}, interactive)
+	}
+	arguments, err := bind(entry.definition, flags.Args())
+	if err != nil {
+		return fmt.Errorf("%s: %w", entry.definition.Name, err)
+	}
+	in, ok := console.In.(*bufio.Reader)
+	if !ok {
+		in = bufio.NewReader(console.In)
+		console.In = in
+	}
+	return entry.command.Handle(&Context{
+		Context:     ctx,
+		Out:         console.Out,
+		Err:         console.Err,
+		console:     console,
+		definition:  entry.definition,
+		arguments:   arguments,
+		flags:       flags,
+		in:          in,
+		interactive: interactive && !*noInteraction,
+	})
+}
+
+// bind binds the positional args to the arguments of the definition
+func bind(definition *Definition, args []string) (map[string][]string, error) {
+	arguments := make(map[string][]string, len(definition.Arguments))
+	for _, argument := range definition.Arguments {
+		switch {
+		case len(args) == 0 && argument.Required:
+			return nil, fmt.Errorf("%w: missing %s", ErrMissingArgument, argument.Name)
+		case len(args) == 0:
+			if argument.Default != "" {
+				arguments[argument.Name] = []string{argument.Default}
+			}
+		case argument.Variadic:
+			arguments[argument.Name], args = args, nil
+		default:
+			arguments[argument.Name], args = args[:1], args[1:]
+		}
+	}
+	if len(args) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTooManyArguments, strings.Join(args, " "))
+	}
+	return arguments, nil
+}
+
+func (console *Console) names() []string {
+	names := make([]string, 0, len(console.commands))
+	for name := range console.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,116 @@
+package console
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/pflag"
+)
+
+// Context input and output of the running command
+type Context struct {
+	context.Context
+	Out io.Writer
+	Err io.Writer
+
+	console     *Console
+	definition  *Definition
+	arguments   map[string][]string
+	flags       *pflag.FlagSet
+	in          *bufio.Reader
+	interactive bool
+}
+
+// Name returns the name of the running command
+func (ctx *Context) Name() string {
+	return ctx.definition.Name
+}
+
+// Argument returns the value of the argument, the first value is returned if it's variadic
+func (ctx *Context) Argument(name string) string {
+	if values := ctx.Arguments(name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Arguments returns the values of the argument
+func (ctx *Context) Arguments(name string) []string {
+	return ctx.arguments[name]
+}
+
+// Option returns the value of the option
+func (ctx *Context) Option(name string) string {
+	flag := ctx.flag(name)
+	if flag.Value.Type() == "stringArray" {
+		values, _ := ctx.flags.GetStringArray(name)
+		return strings.Join(values, ",")
+	}
+	return flag.Value.String()
+}
+
+// BoolOption returns the value of the switch option
+func (ctx *Context) BoolOption(name string) bool {
+	return ctx.Option(name) == "true"
+}
+
+// ArrayOption returns the values of the option declared with "=*"
+func (ctx *Context) ArrayOption(name string) []string {
+	ctx.flag(name)
+	values, err := ctx.flags.GetStringArray(name)
+	if err != nil {
+		panic(err)
+	}
+	return values
+}
+
+// Changed returns whether the option is passed
+func (ctx *Context) Changed(name string) bool {
+	return ctx.flag(name).Changed
+}
+
+// Interactive returns whether the prompts are interactive, it's false if --no-interaction is passed
+func (ctx *Context) Interactive() bool {
+	return ctx.interactive
+}
+
+// Call calls another command with the args
+//
+//	ctx.Call("migrate", "--force")
+func (ctx *Context) Call(name string, args ...string) error {
+	return ctx.console.run(ctx.Context, append([]string{name}, args...), ctx.interactive)
+}
+
+// Line writes a line to the output
+func (ctx *Context) Line(format string, args ...any) {
+	fmt.Fprintf(ctx.Out, format+"\n", args...)
+}
+
+// Error writes a line to the error output
+func (ctx *Context) Error(format string, args ...any) {
+	fmt.Fprintf(ctx.Err, format+"\n", args...)
+}
+
+// Table writes rows as an aligned table to the output, the header line is omitted if headers are empty
+func (ctx *Context) Table(headers []string, rows [][]string) {
+	writer := tabwriter.NewWriter(ctx.Out, 0, 4, 2, ' ', 0)
+	if len(headers) > 0 {
+		fmt.Fprintln(writer, strings.Join(headers, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	_ = writer.Flush()
+}
+
+func (ctx *Context) flag(name string) *pflag.Flag {
+	flag := ctx.flags.Lookup(name)
+	if flag == nil {
+		panic(fmt.Errorf("%w: --%s", ErrOptionNotDefined, name))
+	}
+	return flag
+}
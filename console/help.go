@@ -0,0 +1,99 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+)
+
+// list lists the commands grouped by the namespace, which is the prefix before the colon
+func (console *Console) list(ctx *Context) error {
+	namespace := ctx.Argument("namespace")
+	ctx.Line("%s %s", console.name, console.version)
+	ctx.Line("")
+	ctx.Line("Usage:")
+	ctx.Line("  command [options] [arguments]")
+	ctx.Line("")
+	ctx.Line("Available commands:")
+	width := 0
+	for _, name := range console.names() {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	listed, group := 0, ""
+	for _, name := range console.names() {
+		current, _, ok := strings.Cut(name, ":")
+		if !ok {
+			current = ""
+		}
+		if namespace != "" && current != namespace {
+			continue
+		}
+		if current != group {
+			group = current
+			ctx.Line(" %s", group)
+		}
+		ctx.Line("  %-*s  %s", width, name, console.commands[name].command.Description())
+		listed++
+	}
+	if namespace != "" && listed == 0 {
+		return fmt.Errorf("%w: no commands in namespace %s", ErrCommandNotFound, namespace)
+	}
+	return nil
+}
+
+// help displays the description, usage, arguments and options of the command
+func (console *Console) help(ctx *Context) error {
+	entry, ok := console.commands[ctx.Argument("command_name")]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrCommandNotFound, ctx.Argument("command_name"))
+	}
+	definition := entry.definition
+	ctx.Line("Description:")
+	ctx.Line("  %s", entry.command.Description())
+	ctx.Line("")
+	ctx.Line("Usage:")
+	ctx.Line("  %s", definition.Usage())
+	if len(definition.Arguments) > 0 {
+		ctx.Line("")
+		ctx.Line("Arguments:")
+		rows := make([][]string, 0, len(definition.Arguments))
+		for _, argument := range definition.Arguments {
+			rows = append(rows, []string{"  " + argument.Name, describe(argument.Description, argument.Default)})
+		}
+		ctx.Table(nil, rows)
+	}
+	ctx.Line("")
+	ctx.Line("Options:")
+	rows := make([][]string, 0, len(definition.Options)+2)
+	for _, option := range definition.Options {
+		rows = append(rows, []string{"  " + optionUsage(option), describe(option.Description, option.Default)})
+	}
+	rows = append(rows,
+		[]string{"  -h, --help", "Display help for the command"},
+		[]string{"  -n, --no-interaction", "Do not ask any interactive question"},
+	)
+	ctx.Table(nil, rows)
+	return nil
+}
+
+func optionUsage(option Option) string {
+	usage := "    --" + option.Name
+	if option.Shortcut != "" {
+		usage = "-" + option.Shortcut + ", --" + option.Name
+	}
+	switch option.Kind {
+	case OptionValue:
+		usage += "=" + strings.ToUpper(option.Name)
+	case OptionArray:
+		usage += "=" + strings.ToUpper(option.Name) + " (multiple values allowed)"
+	}
+	return usage
+}
+
+func describe(description, defaultValue string) string {
+	if defaultValue == "" {
+		return description
+	}
+	return fmt.Sprintf("%s [default: %q]", description, defaultValue)
+}
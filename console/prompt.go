@@ -0,0 +1,85 @@
+package console
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Ask asks a question and returns the answer, defaultValue is returned if the answer is empty
+//
+//	name := ctx.Ask("What's your name?", "guest")
+func (ctx *Context) Ask(question, defaultValue string) string {
+	if defaultValue != "" {
+		question = fmt.Sprintf("%s [%s]", question, defaultValue)
+	}
+	answer, ok := ctx.prompt(question)
+	if !ok || answer == "" {
+		return defaultValue
+	}
+	return answer
+}
+
+// Confirm asks a yes/no question, defaultValue is returned if the answer is empty or invalid
+//
+//	if !ctx.Confirm("Do you really wish to run this command?", false) {
+//		return nil
+//	}
+func (ctx *Context) Confirm(question string, defaultValue bool) bool {
+	var hint string
+	if defaultValue {
+		hint = "YES/no"
+	} else {
+		hint = "yes/NO"
+	}
+	answer, ok := ctx.prompt(fmt.Sprintf("%s (%s)", question, hint))
+	if !ok {
+		return defaultValue
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	default:
+		return defaultValue
+	}
+}
+
+// Choice asks to choose one of the choices by value or index, it's asked again if the answer is invalid
+//
+//	driver := ctx.Choice("Which driver?", []string{"memory", "database"}, "memory")
+func (ctx *Context) Choice(question string, choices []string, defaultValue string) string {
+	lines := []string{question}
+	for index, choice := range choices {
+		lines = append(lines, fmt.Sprintf("  [%d] %s", index, choice))
+	}
+	if defaultValue != "" {
+		lines[0] = fmt.Sprintf("%s [%s]", question, defaultValue)
+	}
+	for {
+		answer, ok := ctx.prompt(strings.Join(lines, "\n"))
+		if !ok || answer == "" {
+			return defaultValue
+		}
+		for index, choice := range choices {
+			if answer == choice || answer == fmt.Sprint(index) {
+				return choice
+			}
+		}
+		ctx.Error("Value %q is invalid", answer)
+	}
+}
+
+// prompt writes the question and reads a line, false is returned if it's not interactive or the input is closed
+func (ctx *Context) prompt(question string) (string, bool) {
+	if !ctx.interactive {
+		return "", false
+	}
+	fmt.Fprintf(ctx.Out, "%s\n> ", question)
+	answer, err := ctx.in.ReadString('\n')
+	if err != nil && (err != io.EOF || answer == "") {
+		return "", false
+	}
+	return strings.TrimSpace(answer), true
+}
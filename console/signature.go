@@ -0,0 +1,151 @@
+package console
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	tokenPattern       = regexp.MustCompile(`\{\s*([^}]*?)\s*\}`)
+	descriptionPattern = regexp.MustCompile(`\s+:\s*`)
+)
+
+// Argument positional argument of commands
+type Argument struct {
+	Name        string
+	Description string
+	Required    bool
+	Variadic    bool
+	Default     string
+}
+
+// OptionKind the kind of option values
+type OptionKind int
+
+const (
+	// OptionBool the option is a switch without value, e.g. --force
+	OptionBool OptionKind = iota
+	// OptionValue the option accepts a value, e.g. --step=1
+	OptionValue
+	// OptionArray the option accepts multiple values, e.g. --pool=a --pool=b
+	OptionArray
+)
+
+// Option option of commands
+type Option struct {
+	Name        string
+	Shortcut    string
+	Description string
+	Kind        OptionKind
+	Default     string
+}
+
+// Definition the parsed signature of commands
+type Definition struct {
+	Name      string
+	Arguments []Argument
+	Options   []Option
+}
+
+// Parse parses the signature of commands, it panics if the signature is invalid
+//
+// arguments and options are declared in braces, descriptions follow a colon:
+//
+//	migrate:rollback
+//		{name : required argument}
+//		{name? : optional argument}
+//		{name=default : optional argument with default value}
+//		{name* : required variadic argument}
+//		{name?* : optional variadic argument}
+//		{--force : switch option}
+//		{--S|step=1 : option with shortcut and default value}
+//		{--pool=* : option with multiple values}
+func Parse(signature string) *Definition {
+	signature = strings.TrimSpace(signature)
+	name := signature
+	if index := strings.IndexAny(signature, " \t\n{"); index >= 0 {
+		name = signature[:index]
+	}
+	if name == "" {
+		panic(fmt.Errorf("command name is empty in signature %q", signature))
+	}
+	definition := &Definition{Name: name}
+	for _, match := range tokenPattern.FindAllStringSubmatch(signature[len(name):], -1) {
+		token, description := match[1], ""
+		if parts := descriptionPattern.Split(token, 2); len(parts) == 2 {
+			token, description = parts[0], parts[1]
+		}
+		if strings.HasPrefix(token, "--") {
+			definition.Options = append(definition.Options, parseOption(token[2:], description))
+		} else {
+			definition.addArgument(parseArgument(token, description))
+		}
+	}
+	return definition
+}
+
+func parseArgument(token, description string) Argument {
+	argument := Argument{Name: token, Description: description, Required: true}
+	if name, value, ok := strings.Cut(token, "="); ok {
+		argument.Name, argument.Default, argument.Required = name, value, false
+	}
+	if strings.HasSuffix(argument.Name, "*") {
+		argument.Name, argument.Variadic = strings.TrimSuffix(argument.Name, "*"), true
+	}
+	if strings.HasSuffix(argument.Name, "?") {
+		argument.Name, argument.Required = strings.TrimSuffix(argument.Name, "?"), false
+	}
+	return argument
+}
+
+func parseOption(token, description string) Option {
+	option := Option{Name: token, Description: description, Kind: OptionBool}
+	if name, value, ok := strings.Cut(token, "="); ok {
+		option.Name, option.Default, option.Kind = name, value, OptionValue
+		if value == "*" {
+			option.Default, option.Kind = "", OptionArray
+		}
+	}
+	if shortcut, name, ok := strings.Cut(option.Name, "|"); ok {
+		option.Shortcut, option.Name = shortcut, name
+	}
+	if option.Name == "" || len(option.Shortcut) > 1 {
+		panic(fmt.Errorf("invalid option --%s", token))
+	}
+	return option
+}
+
+func (definition *Definition) addArgument(argument Argument) {
+	if count := len(definition.Arguments); count > 0 {
+		last := definition.Arguments[count-1]
+		if last.Variadic {
+			panic(fmt.Errorf("argument %s can't follow the variadic argument %s", argument.Name, last.Name))
+		}
+		if !last.Required && argument.Required {
+			panic(fmt.Errorf("required argument %s can't follow the optional argument %s", argument.Name, last.Name))
+		}
+	}
+	definition.Arguments = append(definition.Arguments, argument)
+}
+
+// Usage returns the usage line of the command
+//
+//	migrate:rollback [options] [--] <name> [<rest>...]
+func (definition *Definition) Usage() string {
+	usage := []string{definition.Name, "[options]"}
+	if len(definition.Arguments) > 0 {
+		usage = append(usage, "[--]")
+	}
+	for _, argument := range definition.Arguments {
+		item := "<" + argument.Name + ">"
+		if argument.Variadic {
+			item += "..."
+		}
+		if !argument.Required {
+			item = "[" + item + "]"
+		}
+		usage = append(usage, item)
+	}
+	return strings.Join(usage, " ")
+}
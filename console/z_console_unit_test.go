@@ -0,0 +1,169 @@
+package console
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newConsole(input string) (*Console, *bytes.Buffer) {
+	out := new(bytes.Buffer)
+	c := New("app", "1.0.0")
+	c.In, c.Out, c.Err = strings.NewReader(input), out, out
+	return c, out
+}
+
+func TestParse(t *testing.T) {
+	definition := Parse(`migrate:rollback
+		{name : The name}
+		{target=main : The target}
+		{rest?* : The rest}
+		{--force : Force it}
+		{--S|step=1 : The step}
+		{--pool=* : The pools}
+		{--dsn= : The dsn : with colon}`)
+	assert.Equal(t, "migrate:rollback", definition.Name)
+	assert.Equal(t, []Argument{
+		{Name: "name", Description: "The name", Required: true},
+		{Name: "target", Description: "The target", Default: "main"},
+		{Name: "rest", Description: "The rest", Variadic: true},
+	}, definition.Arguments)
+	assert.Equal(t, []Option{
+		{Name: "force", Description: "Force it", Kind: OptionBool},
+		{Name: "step", Shortcut: "S", Description: "The step", Kind: OptionValue, Default: "1"},
+		{Name: "pool", Description: "The pools", Kind: OptionArray},
+		{Name: "dsn", Description: "The dsn : with colon", Kind: OptionValue},
+	}, definition.Options)
+	assert.Equal(t, "migrate:rollback [options] [--] <name> [<target>] [<rest>...]", definition.Usage())
+
+	assert.Panics(t, func() { Parse("") })
+	assert.Panics(t, func() { Parse("cmd {name?} {other}") })
+	assert.Panics(t, func() { Parse("cmd {name*} {other?}") })
+	assert.Panics(t, func() { Parse("cmd {--ab|name}") })
+}
+
+func TestConsole_Run(t *testing.T) {
+	var captured *Context
+	capture := NewCommand("greet {name} {names?*} {--Y|yell} {--times=1} {--tag=*}", "Greet", func(ctx *Context) error {
+		captured = ctx
+		return nil
+	})
+
+	t.Run("arguments and options", func(t *testing.T) {
+		c, _ := newConsole("")
+		c.Register(capture)
+		assert.NoError(t, c.Run(context.Background(), []string{"greet", "john", "-Y", "jane", "joe", "--times=2", "--tag", "a", "--tag", "b,c"}))
+		assert.Equal(t, "greet", captured.Name())
+		assert.Equal(t, "john", captured.Argument("name"))
+		assert.Equal(t, []string{"jane", "joe"}, captured.Arguments("names"))
+		assert.True(t, captured.BoolOption("yell"))
+		assert.Equal(t, "2", captured.Option("times"))
+		assert.True(t, captured.Changed("times"))
+		assert.Equal(t, []string{"a", "b,c"}, captured.ArrayOption("tag"))
+		assert.Panics(t, func() { captured.Option("unknown") })
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		c, _ := newConsole("")
+		c.Register(capture)
+		assert.NoError(t, c.Run(context.Background(), []string{"greet", "john"}))
+		assert.Empty(t, captured.Arguments("names"))
+		assert.False(t, captured.BoolOption("yell"))
+		assert.Equal(t, "1", captured.Option("times"))
+		assert.False(t, captured.Changed("times"))
+		assert.Empty(t, captured.ArrayOption("tag"))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		c, _ := newConsole("")
+		c.Register(capture)
+		assert.ErrorIs(t, c.Run(context.Background(), []string{"unknown"}), ErrCommandNotFound)
+		assert.ErrorIs(t, c.Run(context.Background(), []string{"greet"}), ErrMissingArgument)
+		assert.Error(t, c.Run(context.Background(), []string{"greet", "john", "--unknown"}))
+		assert.Panics(t, func() { c.Register(NewCommand("bad {--help}", "", nil)) })
+		assert.PanicsWithError(t, "shortcut -h of bad is reserved", func() { c.Register(NewCommand("bad {--h|host=}", "", nil)) })
+		assert.PanicsWithError(t, "shortcut -n of bad is reserved", func() { c.Register(NewCommand("bad {--n|name=}", "", nil)) })
+	})
+
+	t.Run("call", func(t *testing.T) {
+		c, out := newConsole("")
+		c.Register(capture, NewCommand("outer", "Outer", func(ctx *Context) error {
+			ctx.Line("outer")
+			return ctx.Call("greet", "john")
+		}))
+		assert.NoError(t, c.Run(context.Background(), []string{"outer"}))
+		assert.Equal(t, "outer\n", out.String())
+		assert.Equal(t, "john", captured.Argument("name"))
+	})
+}
+
+func TestConsole_Help(t *testing.T) {
+	c, out := newConsole("")
+	c.Register(
+		NewCommand("migrate {--force : Force it}", "Run the migrations", nil),
+		NewCommand("migrate:rollback {--S|step=1 : The step}", "Rollback the migrations", nil),
+		NewCommand("queue:work {pools?* : The pools}", "Start the workers", nil),
+	)
+
+	assert.NoError(t, c.Run(context.Background(), nil))
+	assert.Contains(t, out.String(), "app 1.0.0")
+	assert.Regexp(t, `(?s)help\s+Display help.*\n  migrate\s+Run the migrations\n migrate\n  migrate:rollback\s+Rollback.*\n queue\n  queue:work`, out.String())
+
+	out.Reset()
+	assert.NoError(t, c.Run(context.Background(), []string{"list", "queue"}))
+	assert.NotContains(t, out.String(), "migrate")
+	assert.Contains(t, out.String(), "queue:work")
+
+	out.Reset()
+	assert.NoError(t, c.Run(context.Background(), []string{"migrate:rollback", "--help"}))
+	assert.Contains(t, out.String(), "Rollback the migrations")
+	assert.Contains(t, out.String(), "migrate:rollback [options]")
+	assert.Regexp(t, `-S, --step=STEP\s+The step \[default: "1"\]`, out.String())
+
+	out.Reset()
+	assert.NoError(t, c.Run(context.Background(), []string{"help", "queue:work"}))
+	assert.Regexp(t, `Arguments:\n\s+pools\s+The pools`, out.String())
+
+	out.Reset()
+	assert.NoError(t, c.Run(context.Background(), []string{"--version"}))
+	assert.Equal(t, "app 1.0.0\n", out.String())
+}
+
+func TestContext_Prompts(t *testing.T) {
+	run := func(input string, args []string, handle func(ctx *Context)) string {
+		c, out := newConsole(input)
+		c.Register(NewCommand("prompt", "", func(ctx *Context) error {
+			handle(ctx)
+			return nil
+		}))
+		assert.NoError(t, c.Run(context.Background(), append([]string{"prompt"}, args...)))
+		return out.String()
+	}
+
+	run("john\n\n", nil, func(ctx *Context) {
+		assert.Equal(t, "john", ctx.Ask("Name?", "guest"))
+		assert.Equal(t, "guest", ctx.Ask("Name?", "guest"))
+		assert.Equal(t, "guest", ctx.Ask("Name?", "guest"))
+	})
+
+	output := run("yes\nn\nmaybe\n", nil, func(ctx *Context) {
+		assert.True(t, ctx.Confirm("Sure?", false))
+		assert.False(t, ctx.Confirm("Sure?", true))
+		assert.True(t, ctx.Confirm("Sure?", true))
+	})
+	assert.Contains(t, output, "Sure? (yes/NO)\n> ")
+
+	output = run("redis\n1\n", nil, func(ctx *Context) {
+		assert.Equal(t, "database", ctx.Choice("Driver?", []string{"memory", "database"}, "memory"))
+	})
+	assert.Contains(t, output, "  [1] database")
+	assert.Contains(t, output, `Value "redis" is invalid`)
+
+	run("john\n", []string{"-n"}, func(ctx *Context) {
+		assert.False(t, ctx.Interactive())
+		assert.Equal(t, "guest", ctx.Ask("Name?", "guest"))
+	})
+}
@@ -0,0 +1,300 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrNotBound no binding is registered for the type
+	ErrNotBound = errors.New("type is not bound")
+	// ErrScopeRequired scoped types can only be resolved in a scope created by [Container.Scope]
+	ErrScopeRequired = errors.New("scoped type must be resolved in a scope")
+	// ErrCircularDependency the constructors depend on each other
+	ErrCircularDependency = errors.New("circular dependency")
+)
+
+// Lifetime the lifetime of resolved instances
+type Lifetime int
+
+const (
+	// LifetimeTransient a new instance is created on every resolving
+	LifetimeTransient Lifetime = iota
+	// LifetimeSingleton the instance is created once and shared by the container and its scopes
+	LifetimeSingleton
+	// LifetimeScoped the instance is created once per scope, e.g. per request
+	LifetimeScoped
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// binding the constructor of a type
+type binding struct {
+	lifetime    Lifetime
+	constructor reflect.Value
+}
+
+// Container dependency injection container
+//
+// types are bound with constructors, the params of constructors are resolved from the container.
+//
+//	c := container.New()
+//	container.Singleton[*gorm.DB](c, func(config *config.Configuration) (*gorm.DB, error) { ... })
+//	container.Bind[UserRepository](c, NewUserRepository)
+//	repository, err := container.Resolve[UserRepository](c)
+type Container struct {
+	mu           *sync.RWMutex
+	bindings     map[reflect.Type]*binding
+	instances    map[reflect.Type]reflect.Value
+	constructing map[reflect.Type]*sync.Mutex
+	root         *Container
+	scoped       bool
+	providers    *providers
+}
+
+// New creates a new [Container] instance
+func New() *Container {
+	c := &Container{
+		mu:           new(sync.RWMutex),
+		bindings:     make(map[reflect.Type]*binding),
+		instances:    make(map[reflect.Type]reflect.Value),
+		constructing: make(map[reflect.Type]*sync.Mutex),
+		providers:    new(providers),
+	}
+	c.root = c
+	return c
+}
+
+// Scope creates a scope of the container, scoped instances are cached in the scope
+//
+// bindings are shared with the container, singletons are cached in the container.
+func (c *Container) Scope() *Container {
+	return &Container{
+		mu:           new(sync.RWMutex),
+		bindings:     c.root.bindings,
+		instances:    make(map[reflect.Type]reflect.Value),
+		constructing: make(map[reflect.Type]*sync.Mutex),
+		root:         c.root,
+		scoped:       true,
+		providers:    c.root.providers,
+	}
+}
+
+// RegisterType registers the constructor of the type with the lifetime
+//
+// the constructor should be a function which returns the type, or the type and an error,
+// it panics if the constructor is invalid.
+func (c *Container) RegisterType(t reflect.Type, lifetime Lifetime, constructor any) {
+	value := reflect.ValueOf(constructor)
+	if value.Kind() != reflect.Func {
+		panic(fmt.Errorf("constructor of %s should be a function, got %T", t, constructor))
+	}
+	fnType := value.Type()
+	if fnType.NumOut() == 0 || fnType.NumOut() > 2 || !fnType.Out(0).AssignableTo(t) ||
+		(fnType.NumOut() == 2 && fnType.Out(1) != errorType) {
+		panic(fmt.Errorf("constructor of %s should return (%s) or (%s, error), got %s", t, t, t, fnType))
+	}
+	c.root.mu.Lock()
+	defer c.root.mu.Unlock()
+	c.root.bindings[t] = &binding{lifetime: lifetime, constructor: value}
+	delete(c.root.instances, t)
+}
+
+// Instance registers the value as a singleton of the type
+func (c *Container) Instance(t reflect.Type, value any) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		v = reflect.Zero(t)
+	}
+	if !v.Type().AssignableTo(t) {
+		panic(fmt.Errorf("instance of %s should be assignable to it, got %T", t, value))
+	}
+	c.root.mu.Lock()
+	defer c.root.mu.Unlock()
+	c.root.bindings[t] = &binding{lifetime: LifetimeSingleton}
+	c.root.instances[t] = v
+}
+
+// Bound returns whether the type is bound
+func (c *Container) Bound(t reflect.Type) bool {
+	c.root.mu.RLock()
+	defer c.root.mu.RUnlock()
+	_, ok := c.root.bindings[t]
+	return ok
+}
+
+// Resolve resolves the instance of the type
+func (c *Container) Resolve(t reflect.Type) (reflect.Value, error) {
+	return c.resolve(t, nil)
+}
+
+func (c *Container) resolve(t reflect.Type, resolving []reflect.Type) (reflect.Value, error) {
+	for _, r := range resolving {
+		if r == t {
+			return reflect.Value{}, fmt.Errorf("%w: %s", ErrCircularDependency, path(append(resolving, t)))
+		}
+	}
+	c.root.mu.RLock()
+	b, ok := c.root.bindings[t]
+	c.root.mu.RUnlock()
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%w: %s", ErrNotBound, t)
+	}
+	switch b.lifetime {
+	case LifetimeSingleton:
+		return c.root.cached(t, b, append(resolving, t), c)
+	case LifetimeScoped:
+		if !c.scoped {
+			return reflect.Value{}, fmt.Errorf("%w: %s", ErrScopeRequired, t)
+		}
+		return c.cached(t, b, append(resolving, t), c)
+	default:
+		return c.construct(b, append(resolving, t))
+	}
+}
+
+// cached returns the cached instance of the container, the instance is constructed in the scope
+//
+// constructions of the same type are serialized, so that the constructor is called only once.
+func (c *Container) cached(t reflect.Type, b *binding, resolving []reflect.Type, scope *Container) (reflect.Value, error) {
+	if instance, ok := c.instance(t); ok {
+		return instance, nil
+	}
+	lock := c.constructionLock(t)
+	lock.Lock()
+	defer lock.Unlock()
+	// the instance may be constructed while waiting for the lock
+	if instance, ok := c.instance(t); ok {
+		return instance, nil
+	}
+	// dependencies of singletons are resolved from the root container,
+	// so that singletons never capture scoped instances
+	if b.lifetime == LifetimeSingleton {
+		scope = c
+	}
+	instance, err := scope.construct(b, resolving)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.instances[t] = instance
+	return instance, nil
+}
+
+func (c *Container) instance(t reflect.Type) (reflect.Value, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	instance, ok := c.instances[t]
+	return instance, ok
+}
+
+// constructionLock returns the lock held while constructing the instance of the type
+func (c *Container) constructionLock(t reflect.Type) *sync.Mutex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	lock, ok := c.constructing[t]
+	if !ok {
+		lock = new(sync.Mutex)
+		c.constructing[t] = lock
+	}
+	return lock
+}
+
+func (c *Container) construct(b *binding, resolving []reflect.Type) (reflect.Value, error) {
+	args, err := c.arguments(b.constructor.Type(), resolving)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	outputs := b.constructor.Call(args)
+	if len(outputs) == 2 && !outputs[1].IsNil() {
+		return reflect.Value{}, outputs[1].Interface().(error)
+	}
+	return outputs[0], nil
+}
+
+func (c *Container) arguments(fnType reflect.Type, resolving []reflect.Type) ([]reflect.Value, error) {
+	args := make([]reflect.Value, 0, fnType.NumIn())
+	for i := 0; i < fnType.NumIn(); i++ {
+		if fnType.IsVariadic() && i == fnType.NumIn()-1 {
+			break
+		}
+		arg, err := c.resolve(fnType.In(i), resolving)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// Call calls the function with arguments resolved from the container, variadic params are omitted
+//
+//	outputs, err := c.Call(func(db *gorm.DB, log *logger.Logger) error { ... })
+func (c *Container) Call(fn any) ([]reflect.Value, error) {
+	value := reflect.ValueOf(fn)
+	if value.Kind() != reflect.Func {
+		panic(fmt.Errorf("%T is not a function", fn))
+	}
+	args, err := c.arguments(value.Type(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return value.Call(args), nil
+}
+
+// Inject injects the exported fields tagged with `inject` of the struct pointer
+//
+// fields tagged with `inject:"optional"` are skipped if their types are not bound,
+// fields of embedded structs are injected as well.
+//
+//	type UserController struct {
+//		web.Controller
+//		Users UserRepository `inject:""`
+//	}
+func (c *Container) Inject(target any) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		panic(fmt.Errorf("inject target should be a struct pointer, got %T", target))
+	}
+	return c.inject(value.Elem())
+}
+
+func (c *Container) inject(value reflect.Value) error {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		tag, tagged := field.Tag.Lookup("inject")
+		if !tagged {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				if err := c.inject(value.Field(i)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !field.IsExported() {
+			return fmt.Errorf("unexported field %s.%s can't be injected", valueType, field.Name)
+		}
+		if tag == "optional" && !c.Bound(field.Type) {
+			continue
+		}
+		instance, err := c.Resolve(field.Type)
+		if err != nil {
+			return fmt.Errorf("inject %s.%s: %w", valueType, field.Name, err)
+		}
+		value.Field(i).Set(instance)
+	}
+	return nil
+}
+
+func path(types []reflect.Type) string {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, t.String())
+	}
+	return strings.Join(names, " -> ")
+}
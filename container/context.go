@@ -0,0 +1,16 @@
+package container
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a new context carrying the container
+func NewContext(ctx context.Context, c *Container) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the container carried by ctx
+func FromContext(ctx context.Context) (*Container, bool) {
+	c, ok := ctx.Value(contextKey{}).(*Container)
+	return c, ok
+}
@@ -0,0 +1,54 @@
+package container
+
+import "reflect"
+
+// TypeOf returns the reflect type of T, interfaces are supported
+func TypeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// Bind registers a transient constructor of T, a new instance is created on every resolving
+//
+//	container.Bind[UserRepository](c, func(db *gorm.DB) UserRepository { return &userRepository{db} })
+func Bind[T any](c *Container, constructor any) {
+	c.RegisterType(TypeOf[T](), LifetimeTransient, constructor)
+}
+
+// Singleton registers a singleton constructor of T, the instance is created once
+func Singleton[T any](c *Container, constructor any) {
+	c.RegisterType(TypeOf[T](), LifetimeSingleton, constructor)
+}
+
+// Scoped registers a scoped constructor of T, the instance is created once per scope
+func Scoped[T any](c *Container, constructor any) {
+	c.RegisterType(TypeOf[T](), LifetimeScoped, constructor)
+}
+
+// Instance registers the value as the singleton of T
+func Instance[T any](c *Container, value T) {
+	c.Instance(TypeOf[T](), value)
+}
+
+// Resolve resolves the instance of T
+func Resolve[T any](c *Container) (T, error) {
+	value, err := c.Resolve(TypeOf[T]())
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value.Interface().(T), nil
+}
+
+// MustResolve resolves the instance of T, it panics if failed
+func MustResolve[T any](c *Container) T {
+	instance, err := Resolve[T](c)
+	if err != nil {
+		panic(err)
+	}
+	return instance
+}
+
+// Bound returns whether T is bound
+func Bound[T any](c *Container) bool {
+	return c.Bound(TypeOf[T]())
+}
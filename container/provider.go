@@ -0,0 +1,73 @@
+package container
+
+import "sync"
+
+// ServiceProvider registers and boots services of the container
+//
+// Register should only bind services, Boot is called after all providers are registered,
+// so that services of other providers can be resolved.
+type ServiceProvider interface {
+	Register(c *Container) error
+	Boot(c *Container) error
+}
+
+type providers struct {
+	mu      sync.Mutex
+	items   []ServiceProvider
+	booted  int
+	started bool
+}
+
+// Provide registers the providers in order, providers added after [Container.Boot] are booted immediately
+func (c *Container) Provide(providers ...ServiceProvider) error {
+	for _, provider := range providers {
+		if err := provider.Register(c); err != nil {
+			return err
+		}
+		c.providers.mu.Lock()
+		c.providers.items = append(c.providers.items, provider)
+		started := c.providers.started
+		c.providers.mu.Unlock()
+		if started {
+			if err := c.Boot(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Boot boots the registered providers in order, providers are booted only once
+//
+// providers are booted without holding the lock, so that Boot of a provider can call [Container.Provide]
+func (c *Container) Boot() error {
+	for {
+		provider, ok := c.nextProvider()
+		if !ok {
+			return nil
+		}
+		if err := provider.Boot(c.root); err != nil {
+			return err
+		}
+	}
+}
+
+// nextProvider marks the next pending provider as booted and returns it
+func (c *Container) nextProvider() (ServiceProvider, bool) {
+	c.providers.mu.Lock()
+	defer c.providers.mu.Unlock()
+	c.providers.started = true
+	if c.providers.booted >= len(c.providers.items) {
+		return nil, false
+	}
+	provider := c.providers.items[c.providers.booted]
+	c.providers.booted++
+	return provider, true
+}
+
+// Providers returns the registered providers
+func (c *Container) Providers() []ServiceProvider {
+	c.providers.mu.Lock()
+	defer c.providers.mu.Unlock()
+	return append([]ServiceProvider(nil), c.providers.items...)
+}
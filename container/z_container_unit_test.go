@@ -0,0 +1,246 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type greeter interface {
+	Greet() string
+}
+
+type english struct {
+	name string
+}
+
+func (e *english) Greet() string {
+	return "hello " + e.name
+}
+
+type name string
+
+type service struct {
+	Greeter  greeter `inject:""`
+	Name     name    `inject:""`
+	Optional *int    `inject:"optional"`
+	Skipped  *int
+}
+
+func TestContainer_Resolve(t *testing.T) {
+	t.Run("transient", func(t *testing.T) {
+		c := New()
+		Instance[name](c, "john")
+		Bind[greeter](c, func(n name) greeter { return &english{name: string(n)} })
+		first := MustResolve[greeter](c)
+		second := MustResolve[greeter](c)
+		assert.Equal(t, "hello john", first.Greet())
+		assert.NotSame(t, first, second)
+	})
+
+	t.Run("singleton", func(t *testing.T) {
+		c := New()
+		Singleton[*english](c, func() *english { return &english{name: "john"} })
+		assert.Same(t, MustResolve[*english](c), MustResolve[*english](c))
+		assert.Same(t, MustResolve[*english](c), MustResolve[*english](c.Scope()))
+	})
+
+	t.Run("scoped", func(t *testing.T) {
+		c := New()
+		Scoped[*english](c, func() *english { return &english{} })
+		_, err := Resolve[*english](c)
+		assert.ErrorIs(t, err, ErrScopeRequired)
+
+		scope, another := c.Scope(), c.Scope()
+		assert.Same(t, MustResolve[*english](scope), MustResolve[*english](scope))
+		assert.NotSame(t, MustResolve[*english](scope), MustResolve[*english](another))
+	})
+
+	t.Run("singletons can't depend on scoped", func(t *testing.T) {
+		c := New()
+		Scoped[name](c, func() name { return "john" })
+		Singleton[*english](c, func(n name) *english { return &english{name: string(n)} })
+		_, err := Resolve[*english](c.Scope())
+		assert.ErrorIs(t, err, ErrScopeRequired)
+	})
+
+	t.Run("constructor error", func(t *testing.T) {
+		c := New()
+		expected := errors.New("failed")
+		Singleton[*english](c, func() (*english, error) { return nil, expected })
+		_, err := Resolve[*english](c)
+		assert.ErrorIs(t, err, expected)
+	})
+
+	t.Run("not bound", func(t *testing.T) {
+		_, err := Resolve[greeter](New())
+		assert.ErrorIs(t, err, ErrNotBound)
+	})
+
+	t.Run("circular dependency", func(t *testing.T) {
+		c := New()
+		Bind[name](c, func(g greeter) name { return "" })
+		Bind[greeter](c, func(n name) greeter { return nil })
+		_, err := Resolve[greeter](c)
+		assert.ErrorIs(t, err, ErrCircularDependency)
+		assert.Contains(t, err.Error(), "container.greeter -> container.name -> container.greeter")
+	})
+
+	t.Run("concurrent singleton", func(t *testing.T) {
+		c := New()
+		var constructed atomic.Int32
+		Singleton[*english](c, func() *english {
+			constructed.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			return &english{}
+		})
+		instances := make([]*english, 10)
+		wg := new(sync.WaitGroup)
+		for i := range instances {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				instances[i] = MustResolve[*english](c.Scope())
+			}(i)
+		}
+		wg.Wait()
+		for _, instance := range instances {
+			assert.Same(t, MustResolve[*english](c), instance)
+		}
+		assert.EqualValues(t, 1, constructed.Load())
+	})
+
+	t.Run("concurrent scoped", func(t *testing.T) {
+		c := New()
+		var constructed atomic.Int32
+		Scoped[*english](c, func() *english {
+			constructed.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			return &english{}
+		})
+		scope := c.Scope()
+		wg := new(sync.WaitGroup)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				MustResolve[*english](scope)
+			}()
+		}
+		wg.Wait()
+		assert.EqualValues(t, 1, constructed.Load())
+	})
+}
+
+func TestContainer_RegisterType(t *testing.T) {
+	c := New()
+	assert.Panics(t, func() { Bind[greeter](c, "not a function") })
+	assert.Panics(t, func() { Bind[greeter](c, func() name { return "" }) })
+	assert.Panics(t, func() { Bind[greeter](c, func() (greeter, name) { return nil, "" }) })
+	assert.NotPanics(t, func() { Bind[greeter](c, func() (*english, error) { return nil, nil }) })
+	assert.True(t, Bound[greeter](c))
+}
+
+func TestContainer_Inject(t *testing.T) {
+	c := New()
+	Instance[name](c, "john")
+	Bind[greeter](c, func(n name) greeter { return &english{name: string(n)} })
+
+	target := new(struct{ service })
+	assert.NoError(t, c.Inject(target))
+	assert.Equal(t, "hello john", target.Greeter.Greet())
+	assert.Equal(t, name("john"), target.Name)
+	assert.Nil(t, target.Optional)
+	assert.Nil(t, target.Skipped)
+
+	missing := new(struct {
+		Value *int `inject:""`
+	})
+	assert.ErrorIs(t, c.Inject(missing), ErrNotBound)
+	assert.Panics(t, func() { _ = c.Inject(service{}) })
+}
+
+func TestContainer_Call(t *testing.T) {
+	c := New()
+	Instance[name](c, "john")
+	outputs, err := c.Call(func(n name, rest ...int) string { return string(n) })
+	assert.NoError(t, err)
+	assert.Equal(t, "john", outputs[0].String())
+}
+
+func TestContainer_Context(t *testing.T) {
+	c := New()
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+	resolved, ok := FromContext(NewContext(context.Background(), c))
+	assert.True(t, ok)
+	assert.Same(t, c, resolved)
+}
+
+type recorder struct {
+	name   string
+	events *[]string
+	err    error
+	boot   func(c *Container) error
+}
+
+func (r *recorder) Register(c *Container) error {
+	*r.events = append(*r.events, "register "+r.name)
+	return r.err
+}
+
+func (r *recorder) Boot(c *Container) error {
+	*r.events = append(*r.events, "boot "+r.name)
+	if r.boot != nil {
+		return r.boot(c)
+	}
+	return nil
+}
+
+func TestContainer_Providers(t *testing.T) {
+	t.Run("register then boot in order", func(t *testing.T) {
+		events := make([]string, 0)
+		c := New()
+		assert.NoError(t, c.Provide(&recorder{name: "a", events: &events}, &recorder{name: "b", events: &events}))
+		assert.NoError(t, c.Boot())
+		assert.NoError(t, c.Boot())
+		assert.NoError(t, c.Provide(&recorder{name: "c", events: &events}))
+		assert.Equal(t, []string{"register a", "register b", "boot a", "boot b", "register c", "boot c"}, events)
+		assert.Len(t, c.Providers(), 3)
+	})
+
+	t.Run("provide while booting", func(t *testing.T) {
+		events := make([]string, 0)
+		c := New()
+		assert.NoError(t, c.Provide(&recorder{name: "a", events: &events, boot: func(c *Container) error {
+			assert.Len(t, c.Providers(), 1)
+			return c.Provide(&recorder{name: "b", events: &events})
+		}}))
+		done := make(chan error, 1)
+		go func() {
+			done <- c.Boot()
+		}()
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("boot is blocked")
+		}
+		assert.Equal(t, []string{"register a", "boot a", "register b", "boot b"}, events)
+	})
+
+	t.Run("register error", func(t *testing.T) {
+		events := make([]string, 0)
+		expected := errors.New("failed")
+		c := New()
+		err := c.Provide(&recorder{name: "a", events: &events, err: expected}, &recorder{name: "b", events: &events})
+		assert.ErrorIs(t, err, expected)
+		assert.Equal(t, []string{"register a"}, events)
+		assert.Empty(t, c.Providers())
+	})
+}
@@ -1,5 +1,6 @@
 package contract
 
-type ServiceProvider interface {
-	Init()
-}
+import "github.com/wardonne/gopi/container"
+
+// ServiceProvider registers services in Register and boots them in Boot, see [container.ServiceProvider]
+type ServiceProvider = container.ServiceProvider
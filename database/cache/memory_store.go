@@ -0,0 +1,123 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+var _ Store = (*MemoryStore)(nil)
+
+// MemoryStore is an in-memory LRU [Store]
+//
+// The least recently used value is evicted when the capacity is exceeded.
+type MemoryStore struct {
+	mu       *sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+	tags     map[string]map[string]bool
+	now      func() time.Time
+}
+
+type memoryItem struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+	tags      []string
+}
+
+// NewMemoryStore creates a new [MemoryStore] instance, a non-positive capacity means unlimited
+func NewMemoryStore(capacity int) *MemoryStore {
+	return &MemoryStore{
+		mu:       new(sync.Mutex),
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+		tags:     make(map[string]map[string]bool),
+		now:      time.Now,
+	}
+}
+
+// Get implements [Store].Get
+func (store *MemoryStore) Get(key string) ([]byte, bool) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	element, ok := store.items[key]
+	if !ok {
+		return nil, false
+	}
+	item := element.Value.(*memoryItem)
+	if !item.expiresAt.IsZero() && !store.now().Before(item.expiresAt) {
+		store.remove(element)
+		return nil, false
+	}
+	store.order.MoveToFront(element)
+	return item.value, true
+}
+
+// Set implements [Store].Set
+func (store *MemoryStore) Set(key string, value []byte, ttl time.Duration, tags ...string) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if element, ok := store.items[key]; ok {
+		store.remove(element)
+	}
+	item := &memoryItem{key: key, value: value, tags: tags}
+	if ttl > 0 {
+		item.expiresAt = store.now().Add(ttl)
+	}
+	store.items[key] = store.order.PushFront(item)
+	for _, tag := range tags {
+		if _, ok := store.tags[tag]; !ok {
+			store.tags[tag] = make(map[string]bool)
+		}
+		store.tags[tag][key] = true
+	}
+	for store.capacity > 0 && store.order.Len() > store.capacity {
+		store.remove(store.order.Back())
+	}
+}
+
+// Forget implements [Store].Forget
+func (store *MemoryStore) Forget(key string) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if element, ok := store.items[key]; ok {
+		store.remove(element)
+	}
+}
+
+// Flush implements [Store].Flush
+func (store *MemoryStore) Flush(tags ...string) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for _, tag := range tags {
+		for key := range store.tags[tag] {
+			if element, ok := store.items[key]; ok {
+				store.remove(element)
+			}
+		}
+		delete(store.tags, tag)
+	}
+}
+
+// Len returns the number of cached values, including expired ones not evicted yet
+func (store *MemoryStore) Len() int {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.order.Len()
+}
+
+func (store *MemoryStore) remove(element *list.Element) {
+	item := store.order.Remove(element).(*memoryItem)
+	delete(store.items, item.key)
+	for _, tag := range item.tags {
+		if keys, ok := store.tags[tag]; ok {
+			delete(keys, item.key)
+			if len(keys) == 0 {
+				delete(store.tags, tag)
+			}
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package cache
+
+import "time"
+
+// Store is the interface of query cache store
+type Store interface {
+	// Get returns the cached value of key, false is returned if key is missing or expired
+	Get(key string) ([]byte, bool)
+	// Set caches value with key for ttl, a non-positive ttl means no expiration
+	//
+	// tags are used to flush a group of keys, see [Store.Flush]
+	Set(key string, value []byte, ttl time.Duration, tags ...string)
+	// Forget removes the cached value of key
+	Forget(key string)
+	// Flush removes all cached values tagged with any of tags
+	Flush(tags ...string)
+}
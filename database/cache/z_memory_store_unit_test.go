@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	t.Run("get and set", func(t *testing.T) {
+		store := NewMemoryStore(0)
+		store.Set("a", []byte("1"), 0)
+		value, ok := store.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, []byte("1"), value)
+		_, ok = store.Get("b")
+		assert.False(t, ok)
+		store.Forget("a")
+		_, ok = store.Get("a")
+		assert.False(t, ok)
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		store := NewMemoryStore(0)
+		now := time.Now()
+		store.now = func() time.Time { return now }
+		store.Set("a", []byte("1"), time.Minute)
+		_, ok := store.Get("a")
+		assert.True(t, ok)
+		now = now.Add(time.Minute)
+		_, ok = store.Get("a")
+		assert.False(t, ok)
+		assert.Equal(t, 0, store.Len())
+	})
+
+	t.Run("lru eviction", func(t *testing.T) {
+		store := NewMemoryStore(2)
+		store.Set("a", []byte("1"), 0)
+		store.Set("b", []byte("2"), 0)
+		_, _ = store.Get("a")
+		store.Set("c", []byte("3"), 0)
+		assert.Equal(t, 2, store.Len())
+		_, ok := store.Get("b")
+		assert.False(t, ok)
+		_, ok = store.Get("a")
+		assert.True(t, ok)
+		_, ok = store.Get("c")
+		assert.True(t, ok)
+	})
+
+	t.Run("flush tags", func(t *testing.T) {
+		store := NewMemoryStore(0)
+		store.Set("a", []byte("1"), 0, "users")
+		store.Set("b", []byte("2"), 0, "users", "orders")
+		store.Set("c", []byte("3"), 0, "orders")
+		store.Flush("users")
+		_, ok := store.Get("a")
+		assert.False(t, ok)
+		_, ok = store.Get("b")
+		assert.False(t, ok)
+		_, ok = store.Get("c")
+		assert.True(t, ok)
+		assert.Equal(t, 1, store.Len())
+	})
+}
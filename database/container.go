@@ -14,21 +14,32 @@ var defaultAlias = "default"
 func getInstance() *container {
 	once.Do(func() {
 		instance = &container{
-			dbs: maps.NewHashMap[string, *gorm.DB](),
+			dbs: maps.NewSyncHashMap[string, *gorm.DB](),
 		}
 	})
 	return instance
 }
 
 type container struct {
-	dbs *maps.HashMap[string, *gorm.DB]
+	dbs *maps.SyncHashMap[string, *gorm.DB]
 }
 
+// Register registers a database connection with the specific alias
+func Register(alias string, db *gorm.DB) {
+	getInstance().dbs.Set(alias, db)
+}
+
+// Has returns whether a database connection is registered with the specific alias
+func Has(alias string) bool {
+	return getInstance().dbs.ContainsKey(alias)
+}
+
+// DB returns the database connection of the specific alias, default connection will be returned if alias is omitted
 func DB(alias ...string) *gorm.DB {
 	if len(alias) > 0 {
-		return instance.dbs.Get(alias[0])
+		return getInstance().dbs.Get(alias[0])
 	}
-	return instance.dbs.Get(defaultAlias)
+	return getInstance().dbs.Get(defaultAlias)
 }
 
 // var databases = &databaseContainer{
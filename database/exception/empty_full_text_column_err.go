@@ -0,0 +1,18 @@
+package exception
+
+// EmptyFullTextColumnErr empty full-text search column error
+type EmptyFullTextColumnErr struct{}
+
+func (e *EmptyFullTextColumnErr) Error() string {
+	return "full-text search requires non-empty columns"
+}
+
+// NewEmptyFullTextColumnErr creates a new [EmptyFullTextColumnErr]
+func NewEmptyFullTextColumnErr() *EmptyFullTextColumnErr {
+	return &EmptyFullTextColumnErr{}
+}
+
+// ThrowEmptyFullTextColumnErr creates a new [EmptyFullTextColumnErr] and panic
+func ThrowEmptyFullTextColumnErr() {
+	panic(NewEmptyFullTextColumnErr())
+}
@@ -0,0 +1,18 @@
+package exception
+
+// FullTextNotFoundErr full-text search not found error
+type FullTextNotFoundErr struct{}
+
+func (e *FullTextNotFoundErr) Error() string {
+	return "order by relevance requires a full-text search condition, call WhereFullText first"
+}
+
+// NewFullTextNotFoundErr creates a new [FullTextNotFoundErr]
+func NewFullTextNotFoundErr() *FullTextNotFoundErr {
+	return &FullTextNotFoundErr{}
+}
+
+// ThrowFullTextNotFoundErr creates a new [FullTextNotFoundErr] and panic
+func ThrowFullTextNotFoundErr() {
+	panic(NewFullTextNotFoundErr())
+}
@@ -0,0 +1,18 @@
+package exception
+
+// FullTextTableNotFoundErr full-text search table not found error
+type FullTextTableNotFoundErr struct{}
+
+func (e *FullTextTableNotFoundErr) Error() string {
+	return "SQLite full-text search requires a table when searching multiple columns or ordering by relevance"
+}
+
+// NewFullTextTableNotFoundErr creates a new [FullTextTableNotFoundErr]
+func NewFullTextTableNotFoundErr() *FullTextTableNotFoundErr {
+	return &FullTextTableNotFoundErr{}
+}
+
+// ThrowFullTextTableNotFoundErr creates a new [FullTextTableNotFoundErr] and panic
+func ThrowFullTextTableNotFoundErr() {
+	panic(NewFullTextTableNotFoundErr())
+}
@@ -0,0 +1,13 @@
+package exception
+
+// LockWithoutTransactionErr lock without transaction error
+type LockWithoutTransactionErr struct{}
+
+func (e *LockWithoutTransactionErr) Error() string {
+	return "row locks can only be used in a transaction started by Builder.Begin or Builder.Transaction"
+}
+
+// NewLockWithoutTransactionErr creates a new [LockWithoutTransactionErr]
+func NewLockWithoutTransactionErr() *LockWithoutTransactionErr {
+	return &LockWithoutTransactionErr{}
+}
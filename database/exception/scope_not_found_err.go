@@ -0,0 +1,26 @@
+package exception
+
+import "fmt"
+
+// ScopeNotFoundErr scope not found error
+type ScopeNotFoundErr struct {
+	table string
+	name  string
+}
+
+func (e *ScopeNotFoundErr) Error() string {
+	return fmt.Sprintf("scope \"%s\" is not registered for table \"%s\"", e.name, e.table)
+}
+
+// NewScopeNotFoundErr creates a new [ScopeNotFoundErr]
+func NewScopeNotFoundErr(table, name string) *ScopeNotFoundErr {
+	return &ScopeNotFoundErr{
+		table: table,
+		name:  name,
+	}
+}
+
+// ThrowScopeNotFoundErr creates a new [ScopeNotFoundErr] and panic
+func ThrowScopeNotFoundErr(table, name string) {
+	panic(NewScopeNotFoundErr(table, name))
+}
@@ -0,0 +1,22 @@
+package exception
+
+import "fmt"
+
+// SoftDeletesNotEnabledErr soft deletes not enabled error
+type SoftDeletesNotEnabledErr struct {
+	table string
+}
+
+func (e *SoftDeletesNotEnabledErr) Error() string {
+	return fmt.Sprintf("soft deletes is not enabled for table \"%s\"", e.table)
+}
+
+// NewSoftDeletesNotEnabledErr creates a new [SoftDeletesNotEnabledErr]
+func NewSoftDeletesNotEnabledErr(table string) *SoftDeletesNotEnabledErr {
+	return &SoftDeletesNotEnabledErr{table: table}
+}
+
+// ThrowSoftDeletesNotEnabledErr creates a new [SoftDeletesNotEnabledErr] and panic
+func ThrowSoftDeletesNotEnabledErr(table string) {
+	panic(NewSoftDeletesNotEnabledErr(table))
+}
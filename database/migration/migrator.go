@@ -1,6 +1,23 @@
 package migration
 
+import "fmt"
+
 type Migrator interface {
 	Commit()
 	Rollback()
 }
+
+// Named migrators are recorded in the [Repository] by their names, the names should be unique and never changed
+//
+// migrators which aren't named are recorded by their type names, e.g. *migrations.CreateUsersTable
+type Named interface {
+	Name() string
+}
+
+// Name returns the name of the migrator recorded in the [Repository]
+func Name(migrator Migrator) string {
+	if named, ok := migrator.(Named); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("%T", migrator)
+}
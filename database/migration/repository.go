@@ -0,0 +1,68 @@
+package migration
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultTable the default table recording applied migrations
+const DefaultTable = "migrations"
+
+// Repository records applied migrations
+type Repository interface {
+	// Prepare creates the storage of the records if it doesn't exist
+	Prepare() error
+	// Ran returns the names of applied migrations in the order they were applied
+	Ran() ([]string, error)
+	// Log records the migration as applied
+	Log(name string) error
+	// Delete removes the record of the migration
+	Delete(name string) error
+}
+
+// Migration the record of an applied migration
+type Migration struct {
+	ID        uint64     `gorm:"column:id;primaryKey;autoIncrement;not null"`
+	Migration string     `gorm:"column:migration;size:255;uniqueIndex;not null"`
+	CreatedAt *time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+// DatabaseRepository records applied migrations in a database table
+type DatabaseRepository struct {
+	db    *gorm.DB
+	table string
+}
+
+// NewDatabaseRepository creates a new [DatabaseRepository] instance, the table is [DefaultTable] if it's empty
+func NewDatabaseRepository(db *gorm.DB, table string) *DatabaseRepository {
+	if table == "" {
+		table = DefaultTable
+	}
+	return &DatabaseRepository{db: db, table: table}
+}
+
+// Prepare creates the table if it doesn't exist
+func (r *DatabaseRepository) Prepare() error {
+	if r.db.Migrator().HasTable(r.table) {
+		return nil
+	}
+	return r.db.Table(r.table).Migrator().CreateTable(new(Migration))
+}
+
+// Ran returns the names of applied migrations in the order they were applied
+func (r *DatabaseRepository) Ran() ([]string, error) {
+	names := make([]string, 0)
+	err := r.db.Table(r.table).Order("id").Pluck("migration", &names).Error
+	return names, err
+}
+
+// Log records the migration as applied
+func (r *DatabaseRepository) Log(name string) error {
+	return r.db.Table(r.table).Create(&Migration{Migration: name}).Error
+}
+
+// Delete removes the record of the migration
+func (r *DatabaseRepository) Delete(name string) error {
+	return r.db.Table(r.table).Where("migration = ?", name).Delete(new(Migration)).Error
+}
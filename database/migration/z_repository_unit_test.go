@@ -0,0 +1,72 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type createUsersTable struct{}
+
+func (m *createUsersTable) Commit()   {}
+func (m *createUsersTable) Rollback() {}
+
+type createPostsTable struct {
+	createUsersTable
+}
+
+func (m *createPostsTable) Name() string {
+	return "2024_01_01_create_posts_table"
+}
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.Nil(t, err)
+	mockDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	assert.Nil(t, err)
+	return mockDB, mock
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "*migration.createUsersTable", Name(new(createUsersTable)))
+	assert.Equal(t, "2024_01_01_create_posts_table", Name(new(createPostsTable)))
+}
+
+func TestDatabaseRepository(t *testing.T) {
+	db, mock := newMockDB(t)
+	repository := NewDatabaseRepository(db, "")
+
+	t.Run("prepare", func(t *testing.T) {
+		mock.ExpectQuery("SELECT DATABASE()").WillReturnRows(sqlmock.NewRows([]string{"DATABASE()"}).AddRow("app"))
+		mock.ExpectQuery("SELECT SCHEMA_NAME from Information_schema.SCHEMATA where SCHEMA_NAME LIKE ? ORDER BY SCHEMA_NAME=? DESC,SCHEMA_NAME limit 1").WithArgs("app%", "app").WillReturnRows(sqlmock.NewRows([]string{"SCHEMA_NAME"}).AddRow("app"))
+		mock.ExpectQuery("SELECT count(*) FROM information_schema.tables WHERE table_schema = ? AND table_name = ? AND table_type = ?").WithArgs("app", "migrations", "BASE TABLE").WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+		mock.ExpectExec("CREATE TABLE `migrations` (`id` bigint unsigned AUTO_INCREMENT NOT NULL,`migration` varchar(255) NOT NULL,`created_at` datetime(3) NULL,PRIMARY KEY (`id`),UNIQUE INDEX `idx_migrations_migration` (`migration`))").WillReturnResult(sqlmock.NewResult(0, 0))
+		assert.Nil(t, repository.Prepare())
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ran", func(t *testing.T) {
+		mock.ExpectQuery("SELECT `migration` FROM `migrations` ORDER BY id").WillReturnRows(sqlmock.NewRows([]string{"migration"}).AddRow("a").AddRow("b"))
+		ran, err := repository.Ran()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b"}, ran)
+	})
+
+	t.Run("log", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO `migrations` (`migration`,`created_at`) VALUES (?,?)").WithArgs("c", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(3, 1))
+		assert.Nil(t, repository.Log("c"))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM `migrations` WHERE migration = ?").WithArgs("c").WillReturnResult(sqlmock.NewResult(0, 1))
+		assert.Nil(t, repository.Delete("c"))
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
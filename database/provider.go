@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+
+	di "github.com/wardonne/gopi/container"
+	"gorm.io/gorm"
+)
+
+// Provider opens the database connection and registers it with the alias on booting
+//
+// the connection of the default alias is registered into the container as a singleton of *gorm.DB as well.
+type Provider struct {
+	alias     string
+	dialector gorm.Dialector
+	opts      []gorm.Option
+}
+
+// NewProvider creates a new [Provider] instance
+//
+//	c.Provide(database.NewProvider("default", mysql.Open(dsn), &gorm.Config{}))
+func NewProvider(alias string, dialector gorm.Dialector, opts ...gorm.Option) *Provider {
+	return &Provider{alias: alias, dialector: dialector, opts: opts}
+}
+
+// Register registers the connection of the default alias as a singleton
+func (p *Provider) Register(c *di.Container) error {
+	if p.alias == defaultAlias {
+		di.Singleton[*gorm.DB](c, p.open)
+	}
+	return nil
+}
+
+// Boot opens the connection and registers it with the alias, see [Register]
+func (p *Provider) Boot(c *di.Container) error {
+	var db *gorm.DB
+	var err error
+	if p.alias == defaultAlias {
+		db, err = di.Resolve[*gorm.DB](c)
+	} else {
+		db, err = p.open()
+	}
+	if err != nil {
+		return err
+	}
+	Register(p.alias, db)
+	return nil
+}
+
+// Shutdown closes the connection
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if !Has(p.alias) {
+		return nil
+	}
+	sqlDB, err := DB(p.alias).DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+func (p *Provider) open() (*gorm.DB, error) {
+	return gorm.Open(p.dialector, p.opts...)
+}
@@ -52,3 +52,19 @@ func (builder *Builder) OrderDesc(column any) *Builder {
 	builder = builder.instance()
 	return builder.Order(column, true)
 }
+
+// OrderByRaw add order by clause by raw sql
+//
+//	builder.OrderByRaw("FIELD(status, ?, ?)", "paid", "pending") // ORDER BY FIELD(status, 'paid', 'pending')
+//	builder.OrderByRaw("created_at DESC")
+func (builder *Builder) OrderByRaw(sql string, bindings ...any) *Builder {
+	builder = builder.instance()
+	builder.db = builder.db.Order(clause.OrderByColumn{
+		Column: clause.Column{
+			Name: sql,
+			Raw:  true,
+		},
+	})
+	builder.rawOrders.Add(sql, builder.FormatValues(bindings...))
+	return builder
+}
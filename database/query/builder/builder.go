@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	queryclause "github.com/wardonne/gopi/database/query/clause"
 	"github.com/wardonne/gopi/support/collection/list"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -15,9 +16,21 @@ type Builder struct {
 	db                    *gorm.DB
 	conn                  *gorm.DB
 	distinct              bool
-	selects               *list.ArrayList[clause.Column]
+	selects               *list.ArrayList[selectColumn]
 	joins                 *list.ArrayList[clause.Join]
 	having                *list.ArrayList[clause.Expression]
+	setOperations         *list.ArrayList[queryclause.SetOperation]
+	ctes                  *list.ArrayList[queryclause.CTE]
+	rawOrders             queryclause.RawColumns
+	rawGroups             queryclause.RawColumns
+	fullText              *queryclause.FullText
+	lock                  queryclause.Lock
+	remember              *rememberOption
+	table                 string
+	tableAlias            string
+	excludedScopes        map[string]bool
+	withoutGlobalScopes   bool
+	scopesApplied         bool
 	transactionLevel      uint
 	onTransaction         bool
 	onTransactionBuilding bool
@@ -31,9 +44,15 @@ func NewBuilder(db *gorm.DB) *Builder {
 	return &Builder{
 		db:      db,
 		conn:    db,
-		selects: list.NewArrayList[clause.Column](),
+		selects: list.NewArrayList[selectColumn](),
 		joins:   list.NewArrayList[clause.Join](),
 		having:  list.NewArrayList[clause.Expression](),
+
+		setOperations:  list.NewArrayList[queryclause.SetOperation](),
+		ctes:           list.NewArrayList[queryclause.CTE](),
+		rawOrders:      queryclause.RawColumns{},
+		rawGroups:      queryclause.RawColumns{},
+		excludedScopes: make(map[string]bool),
 	}
 }
 
@@ -43,9 +62,14 @@ func (builder *Builder) instance() *Builder {
 			return &Builder{
 				db:               builder.db.Session(&gorm.Session{NewDB: true}),
 				conn:             builder.conn,
-				selects:          list.NewArrayList[clause.Column](),
+				selects:          list.NewArrayList[selectColumn](),
 				joins:            list.NewArrayList[clause.Join](),
 				having:           list.NewArrayList[clause.Expression](),
+				setOperations:    list.NewArrayList[queryclause.SetOperation](),
+				ctes:             list.NewArrayList[queryclause.CTE](),
+				rawOrders:        queryclause.RawColumns{},
+				rawGroups:        queryclause.RawColumns{},
+				excludedScopes:   make(map[string]bool),
 				transactionLevel: builder.transactionLevel,
 				onTransaction:    true,
 			}
@@ -60,10 +84,9 @@ func (builder *Builder) instance() *Builder {
 }
 
 func (builder *Builder) addClauses() *Builder {
-	var selectClause = clause.Select{
-		Distinct: builder.distinct,
-		Columns:  builder.selects.ToArray(),
-	}
+	builder.applyGlobalScopes()
+	builder.checkLock()
+	var selectClause = builder.selectClause()
 	var joinClause = clause.From{
 		Joins: builder.joins.ToArray(),
 	}
@@ -71,6 +94,9 @@ func (builder *Builder) addClauses() *Builder {
 		Having: builder.having.ToArray(),
 	}
 	builder.db = builder.db.Clauses(selectClause, joinClause, groupClause)
+	builder.addRawColumns()
+	builder.addSetOperations()
+	builder.addCTEs()
 	return builder
 }
 
@@ -96,7 +122,9 @@ func (builder *Builder) DryRun() *Builder {
 	return builder
 }
 
-// WithContext bind context to builder
+// WithContext bind context to builder, queries are canceled once ctx is done
+//
+//	builder.WithContext(request.Context()).Find(&users)
 func (builder *Builder) WithContext(ctx context.Context) *Builder {
 	builder.db = builder.db.Session(&gorm.Session{
 		Context: ctx,
@@ -104,6 +132,18 @@ func (builder *Builder) WithContext(ctx context.Context) *Builder {
 	return builder
 }
 
+// Context returns the context bound to builder
+func (builder *Builder) Context() context.Context {
+	return builder.db.Statement.Context
+}
+
+// AddError adds an error to builder, the query won't be executed and the error will be returned
+func (builder *Builder) AddError(err error) *Builder {
+	builder.db = builder.db.Session(&gorm.Session{})
+	_ = builder.db.AddError(err)
+	return builder
+}
+
 // Assign assign attributes
 func (builder *Builder) Assign(attrs ...any) *Builder {
 	builder = builder.instance()
@@ -3,15 +3,35 @@ package builder
 import (
 	"fmt"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 // Count counts matched records
+//
+// the combined rows are counted when the builder has set operations
 func (builder *Builder) Count() (int64, error) {
+	if !builder.setOperations.IsEmpty() {
+		return builder.countSetOperations()
+	}
 	builder.selects.Clear()
 	builder.onExecutionFinished = true
 	var dest int64
-	err := builder.DB().Count(&dest).Error
+	err := builder.cached(&dest, func(tx *gorm.DB) *gorm.DB {
+		return tx.Count(&dest)
+	})
+	return dest, err
+}
+
+// countSetOperations counts the combined rows of set operations in a derived table
+func (builder *Builder) countSetOperations() (int64, error) {
+	builder.onExecutionFinished = true
+	var dest int64
+	err := builder.cached(&dest, func(tx *gorm.DB) *gorm.DB {
+		return tx.Session(&gorm.Session{NewDB: true}).
+			Table("(?) AS "+builder.QuoteField("aggregate"), tx).
+			Count(&dest)
+	})
 	return dest, err
 }
 
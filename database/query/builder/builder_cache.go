@@ -0,0 +1,151 @@
+package builder
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wardonne/gopi/database/cache"
+	"github.com/wardonne/gopi/support/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CacheKeyPrefix the prefix of generated cache keys
+const CacheKeyPrefix = "gopi:query:"
+
+var (
+	cacheStore   cache.Store
+	cacheStoreMu sync.RWMutex
+)
+
+func init() {
+	// time values scanned into map[string]any are encoded as interface values
+	gob.Register(time.Time{})
+}
+
+// SetCacheStore sets the store used by [Builder.Remember], nil disables caching
+func SetCacheStore(store cache.Store) {
+	cacheStoreMu.Lock()
+	defer cacheStoreMu.Unlock()
+	cacheStore = store
+}
+
+func currentCacheStore() cache.Store {
+	cacheStoreMu.RLock()
+	defer cacheStoreMu.RUnlock()
+	return cacheStore
+}
+
+// FlushCache removes the cached results of tables
+//
+// tables can be table names or model instances
+func FlushCache(tables ...any) {
+	store := currentCacheStore()
+	if store == nil {
+		return
+	}
+	tags := make([]string, 0, len(tables))
+	for _, table := range tables {
+		if name := TableName(table); name != "" {
+			tags = append(tags, name)
+		}
+	}
+	store.Flush(tags...)
+}
+
+type rememberOption struct {
+	ttl time.Duration
+	key string
+}
+
+// Remember caches the results of Find/First/Count/Pluck for ttl in the store set by [SetCacheStore]
+//
+// Results are encoded by gob into the type of dest, so int64 and time.Time values are kept on a hit.
+// Custom types stored in interface values, e.g. in map[string]any, should be registered by gob.Register.
+//
+// The cache key is derived from the sql and its bindings unless key is given.
+// Cached results are tagged with the queried tables, and are flushed after writes through [Builder].
+//
+//	builder.Table("users").Where("status", 1).Remember(time.Minute).Count()
+//	builder.Table("users").Remember(time.Minute, "users:all").Find(&users)
+func (builder *Builder) Remember(ttl time.Duration, key ...string) *Builder {
+	builder = builder.instance()
+	builder.remember = &rememberOption{ttl: ttl}
+	if len(key) > 0 {
+		builder.remember.key = key[0]
+	}
+	return builder
+}
+
+// cached executes query with the result cache, dest is filled from the store on hit
+func (builder *Builder) cached(dest any, query func(tx *gorm.DB) *gorm.DB) error {
+	db := builder.DB()
+	store := currentCacheStore()
+	if builder.remember == nil || store == nil || db.Error != nil {
+		return query(db).Error
+	}
+	key := builder.remember.key
+	if key == "" {
+		tx := query(db.Session(&gorm.Session{DryRun: true}))
+		if tx.Error != nil {
+			return tx.Error
+		}
+		bindings, err := utils.JSONEncode(tx.Statement.Vars)
+		if err != nil {
+			return err
+		}
+		hash := sha1.Sum([]byte(tx.Statement.SQL.String() + string(bindings)))
+		key = hex.EncodeToString(hash[:])
+	}
+	key = CacheKeyPrefix + key
+	if value, ok := store.Get(key); ok {
+		if err := gob.NewDecoder(bytes.NewReader(value)).Decode(dest); err == nil {
+			return nil
+		}
+		store.Forget(key)
+	}
+	if err := query(db).Error; err != nil {
+		return err
+	}
+	var value bytes.Buffer
+	if err := gob.NewEncoder(&value).Encode(dest); err != nil {
+		return fmt.Errorf("cache query result: %w", err)
+	}
+	store.Set(key, value.Bytes(), builder.remember.ttl, builder.cacheTags()...)
+	return nil
+}
+
+// cacheTags returns the tables queried by builder
+func (builder *Builder) cacheTags() []string {
+	tags := make([]string, 0, builder.joins.Count()+1)
+	if table := builder.tableName(); table != "" {
+		tags = append(tags, table)
+	}
+	builder.joins.Range(func(join clause.Join) bool {
+		if table, _ := parseJoinTable(join.Table); table != "" {
+			tags = append(tags, table)
+		}
+		return true
+	})
+	return tags
+}
+
+// flushCache flushes the cached results of the table after a successful write,
+// the table is resolved from models if neither table nor model is set
+func (builder *Builder) flushCache(err error, models ...any) error {
+	store := currentCacheStore()
+	if err != nil || store == nil {
+		return err
+	}
+	if table := builder.tableName(); table != "" {
+		store.Flush(table)
+		return nil
+	}
+	FlushCache(models...)
+	return nil
+}
@@ -0,0 +1,192 @@
+package builder
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultChunkColumn default key column of keyset chunking
+const DefaultChunkColumn = "id"
+
+// ChunkById find all matched records in batches of batchSize by keyset pagination
+//
+// Records are ordered by column (default is [DefaultChunkColumn]) and every batch starts after the last key of the previous batch,
+// so records are neither skipped nor repeated when the previous batches are updated or deleted in callback.
+// The key column must be unique and sortable, and the order of builder is replaced.
+// dest is reused by all batches.
+//
+//	var users []User
+//	builder.Table("users").Where("status", 1).ChunkById(&users, 100, func(batch int) error {
+//		for _, user := range users {
+//			// do something
+//		}
+//		return nil
+//	})
+//	builder.Table("users").ChunkById(&users, 100, callback, "users.id")
+func (builder *Builder) ChunkById(dest any, batchSize int, callback func(batch int) error, column ...string) error {
+	return builder.chunkById(batchSize, column, func() any {
+		return dest
+	}, func(dest any, batch int) error {
+		return callback(batch)
+	})
+}
+
+// ChunkById find all matched records in batches of batchSize by keyset pagination with typed items,
+// see [Builder.ChunkById]
+//
+//	builder.ChunkById(NewBuilder(db).Table("users"), 100, func(users []User, batch int) error {
+//		return nil
+//	})
+func ChunkById[T any](builder *Builder, batchSize int, callback func(items []T, batch int) error, column ...string) error {
+	return builder.chunkById(batchSize, column, func() any {
+		return &[]T{}
+	}, func(dest any, batch int) error {
+		return callback(*dest.(*[]T), batch)
+	})
+}
+
+// EachById iterates all matched records one by one, records are queried in batches of batchSize, see [Builder.ChunkById]
+//
+//	builder.EachById(NewBuilder(db).Table("users"), 100, func(user User) error {
+//		return nil
+//	})
+func EachById[T any](builder *Builder, batchSize int, callback func(item T) error, column ...string) error {
+	return ChunkById(builder, batchSize, func(items []T, batch int) error {
+		for _, item := range items {
+			if err := callback(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, column...)
+}
+
+// ChunkParallel handles the batches of [ChunkById] with at most n goroutines
+//
+// Batches are still queried one by one, no more batches are queried once a callback fails.
+// All errors returned by callback are joined.
+//
+//	builder.ChunkParallel(NewBuilder(db).Table("users"), 100, 4, func(users []User, batch int) error {
+//		return nil
+//	})
+func ChunkParallel[T any](builder *Builder, batchSize int, n int, callback func(items []T, batch int) error, column ...string) error {
+	if n < 1 {
+		n = 1
+	}
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		errs      []error
+		semaphore = make(chan struct{}, n)
+	)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(errs) > 0
+	}
+	err := ChunkById(builder, batchSize, func(items []T, batch int) error {
+		if failed() {
+			return errChunkStopped
+		}
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			if err := callback(items, batch); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+		return nil
+	}, column...)
+	wg.Wait()
+	if err != nil && !errors.Is(err, errChunkStopped) {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+var errChunkStopped = errors.New("chunk stopped")
+
+func (builder *Builder) chunkById(batchSize int, column []string, newDest func() any, handle func(dest any, batch int) error) error {
+	builder.onExecutionFinished = true
+	key := DefaultChunkColumn
+	if len(column) > 0 && column[0] != "" {
+		key = column[0]
+	}
+	alias := key
+	if idx := strings.LastIndex(key, "."); idx >= 0 {
+		alias = key[idx+1:]
+	}
+	db := builder.guessTable(newDest()).DB()
+	// the keyset condition must apply to every OR branch of the conditions
+	builder.groupWhereConditions()
+	var last any
+	for batch := 1; ; batch++ {
+		// a session with context clones the statement, the order of builder is removed from the clone
+		tx := db.Session(&gorm.Session{Context: db.Statement.Context})
+		delete(tx.Statement.Clauses, "ORDER BY")
+		if last != nil {
+			tx = tx.Where(clause.Gt{Column: clause.Column{Name: key}, Value: last})
+		}
+		dest := newDest()
+		if rv := reflect.ValueOf(dest); rv.Kind() == reflect.Ptr && rv.Elem().Kind() == reflect.Slice {
+			rv.Elem().SetLen(0)
+		}
+		tx = tx.Order(clause.OrderByColumn{Column: clause.Column{Name: key}}).Limit(batchSize).Find(dest)
+		if tx.Error != nil {
+			return tx.Error
+		}
+		count, value := lastKey(tx, dest, alias)
+		if count == 0 {
+			return nil
+		}
+		if err := handle(dest, batch); err != nil {
+			return err
+		}
+		if count < batchSize {
+			return nil
+		}
+		if value == nil {
+			return errors.New("chunk key \"" + alias + "\" is not found in results")
+		}
+		last = value
+	}
+}
+
+// lastKey returns the number of records in dest and the key of the last record
+func lastKey(tx *gorm.DB, dest any, key string) (int, any) {
+	rv := reflect.Indirect(reflect.ValueOf(dest))
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return 0, nil
+	}
+	if rv.Len() == 0 {
+		return 0, nil
+	}
+	item := reflect.Indirect(rv.Index(rv.Len() - 1))
+	switch item.Kind() {
+	case reflect.Map:
+		if value := item.MapIndex(reflect.ValueOf(key)); value.IsValid() {
+			return rv.Len(), value.Interface()
+		}
+	case reflect.Struct:
+		if tx.Statement.Schema != nil {
+			if field := tx.Statement.Schema.LookUpField(key); field != nil {
+				value, zero := field.ValueOf(tx.Statement.Context, item)
+				if !zero {
+					return rv.Len(), value
+				}
+			}
+		}
+	}
+	return rv.Len(), nil
+}
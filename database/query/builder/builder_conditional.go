@@ -0,0 +1,42 @@
+package builder
+
+// When applies the callback if the condition is true, otherwise applies the fallback if it's given
+//
+//	builder.When(form.Name != "", func(builder *Builder) *Builder {
+//		return builder.Where("name", form.Name)
+//	})
+//	builder.When(form.Sort == "oldest", func(builder *Builder) *Builder {
+//		return builder.OrderAsc("created_at")
+//	}, func(builder *Builder) *Builder {
+//		return builder.OrderDesc("created_at")
+//	})
+func (builder *Builder) When(condition bool, callback Clause, fallback ...Clause) *Builder {
+	builder = builder.instance()
+	if condition {
+		return callback(builder)
+	}
+	if len(fallback) > 0 && fallback[0] != nil {
+		return fallback[0](builder)
+	}
+	return builder
+}
+
+// Unless applies the callback if the condition is false, otherwise applies the fallback if it's given
+//
+//	builder.Unless(user.IsAdmin(), func(builder *Builder) *Builder {
+//		return builder.Where("owner_id", user.ID)
+//	})
+func (builder *Builder) Unless(condition bool, callback Clause, fallback ...Clause) *Builder {
+	return builder.When(!condition, callback, fallback...)
+}
+
+// Tap applies the callback to the builder, it helps to reuse query pieces in a chain
+//
+//	published := func(builder *Builder) *Builder {
+//		return builder.Where("status", 1).WhereNotNull("published_at")
+//	}
+//	builder.Table("posts").Tap(published).OrderDesc("published_at")
+func (builder *Builder) Tap(callback Clause) *Builder {
+	builder = builder.instance()
+	return callback(builder)
+}
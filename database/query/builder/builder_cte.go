@@ -0,0 +1,60 @@
+package builder
+
+import (
+	"github.com/wardonne/gopi/database/exception"
+	queryclause "github.com/wardonne/gopi/database/query/clause"
+	"gorm.io/gorm"
+)
+
+// WithCTE prepends a common table expression, which can be selected or joined by name
+//
+//	builder.WithCTE("active_users", NewBuilder(db).Table("users").Where("status", 1)).
+//		Table("active_users").
+//		InnerJoin("orders", "orders.user_id = active_users.id")
+//	// WITH `active_users` AS (SELECT * FROM `users` WHERE `status` = 1) SELECT * FROM `active_users` INNER JOIN orders ON orders.user_id = active_users.id
+func (builder *Builder) WithCTE(name string, query any, columns ...string) *Builder {
+	builder = builder.instance()
+	builder.ctes.Add(queryclause.CTE{
+		Name:    name,
+		Columns: columns,
+		Query:   builder.cteQuery("WithCTE", query),
+	})
+	return builder
+}
+
+// WithRecursive prepends a recursive common table expression, the anchor and recursive queries are combined by UNION ALL
+//
+//	builder.WithRecursive("tree",
+//		NewBuilder(db).Table("categories").WhereNull("parent_id"),
+//		NewBuilder(db).Table("categories").Select("categories.id", "categories.parent_id").InnerJoin("tree", "tree.id = categories.parent_id"),
+//	).Table("tree")
+//	// WITH RECURSIVE `tree` AS (SELECT * FROM `categories` WHERE `parent_id` IS NULL UNION ALL
+//	// SELECT `categories`.`id`,`categories`.`parent_id` FROM `categories` INNER JOIN tree ON tree.id = categories.parent_id) SELECT * FROM `tree`
+func (builder *Builder) WithRecursive(name string, anchor, recursive any, columns ...string) *Builder {
+	builder = builder.instance()
+	builder.ctes.Add(queryclause.CTE{
+		Name:      name,
+		Columns:   columns,
+		Query:     builder.cteQuery("WithRecursive", anchor),
+		Recursive: builder.cteQuery("WithRecursive", recursive),
+	})
+	return builder
+}
+
+func (builder *Builder) cteQuery(method string, query any) *gorm.DB {
+	db, ok := builder.FormatValue(query).(*gorm.DB)
+	if !ok {
+		exception.ThrowInvalidParamTypeErr(method, query)
+	}
+	return db
+}
+
+// addCTEs builds common table expressions right before SELECT clause
+func (builder *Builder) addCTEs() {
+	if builder.ctes.IsEmpty() {
+		return
+	}
+	c := builder.db.Statement.Clauses["SELECT"]
+	c.BeforeExpression = queryclause.With{CTEs: builder.ctes.ToArray()}
+	builder.db.Statement.Clauses["SELECT"] = c
+}
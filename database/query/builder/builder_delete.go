@@ -1,7 +1,49 @@
 package builder
 
+import (
+	queryclause "github.com/wardonne/gopi/database/query/clause"
+	"gorm.io/gorm/clause"
+)
+
 // Delete deletes all matched records
+//
+// if soft deletes is enabled for the table, the deleted column will be updated instead,
+// see [RegisterSoftDeletes]
+//
+// if there are joins, only the records of current table are deleted, see [queryclause.JoinedDelete]
+//
+//	builder.Table("users").InnerJoin("bans", "bans.user_id = users.id").Delete()
+//	// MySQL: DELETE `users` FROM `users` INNER JOIN bans ON bans.user_id = users.id
+//	// PostgreSQL: DELETE FROM "users" USING bans WHERE bans.user_id = users.id
 func (builder *Builder) Delete() error {
+	if column, ok := builder.deletedAtColumn(); ok {
+		if builder.joins.Count() > 0 {
+			return builder.UpdateFrom(map[string]any{builder.QualifyColumn(column): builder.conn.NowFunc()})
+		}
+		builder.onExecutionFinished = true
+		return builder.flushCache(builder.DB().Updates(map[string]any{column: builder.conn.NowFunc()}).Error)
+	}
 	builder.onExecutionFinished = true
-	return builder.DB().Delete(nil).Error
+	if builder.joins.Count() > 0 {
+		return builder.joinedDelete()
+	}
+	return builder.flushCache(builder.DB().Delete(nil).Error)
+}
+
+func (builder *Builder) joinedDelete() error {
+	db := builder.DB()
+	where, _ := db.Statement.Clauses["WHERE"].Expression.(clause.Where)
+	if db.Statement.Table == "" {
+		db.Statement.Table = builder.tableName()
+	}
+	target := builder.tableAlias
+	if target == "" {
+		target = builder.tableName()
+	}
+	tx := db.Exec("?", queryclause.JoinedDelete{
+		Target: target,
+		Joins:  builder.joins.ToArray(),
+		Where:  where,
+	})
+	return builder.flushCache(tx.Error)
 }
@@ -0,0 +1,141 @@
+package builder
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FilterOperator filter operator
+type FilterOperator string
+
+// Filter operators
+const (
+	// FilterEq `field = value`
+	FilterEq FilterOperator = "eq"
+	// FilterLike `field LIKE %value%`
+	FilterLike FilterOperator = "like"
+	// FilterIn `field IN (values)`, values can be a slice or a comma separated string
+	FilterIn FilterOperator = "in"
+	// FilterBetween `field BETWEEN start AND end`, values can be a slice of two or a comma separated string
+	FilterBetween FilterOperator = "between"
+	// FilterSort allows the field to be sorted by the [FilterSortKey] filter
+	FilterSort FilterOperator = "sort"
+)
+
+// FilterSortKey the filter key of sorting, the value is a comma separated string or a slice of fields,
+// fields prefixed with `-` are sorted descending
+//
+//	map[string]any{"sort": "-created_at,name"}
+const FilterSortKey = "sort"
+
+// FilterRules whitelisted operators of fields, filters of other fields or operators are ignored
+//
+//	FilterRules{
+//		"name":       {FilterEq, FilterLike},
+//		"status":     {FilterEq, FilterIn},
+//		"created_at": {FilterBetween, FilterSort},
+//	}
+type FilterRules map[string][]FilterOperator
+
+// Allows returns whether the operator is allowed for the field
+func (rules FilterRules) Allows(field string, operator FilterOperator) bool {
+	for _, allowed := range rules[field] {
+		if allowed == operator {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter applies request filters to the builder, only the operators whitelisted by rules are applied
+//
+// A filter value is either a scalar compared with [FilterEq], or a map of operators to values
+//
+//	builder.Table("users").Filter(map[string]any{
+//		"status":     map[string]any{"in": "1,2"},
+//		"name":       map[string]any{"like": "john"},
+//		"created_at": map[string]any{"between": []string{"2023-01-01", "2023-12-31"}},
+//		"role":       "admin",
+//		"sort":       "-created_at",
+//	}, rules)
+//	// WHERE `status` IN ('1','2') AND `name` LIKE '%john%' AND `created_at` BETWEEN '2023-01-01' AND '2023-12-31' AND `role` = 'admin' ORDER BY `created_at` DESC
+func (builder *Builder) Filter(filters map[string]any, rules FilterRules) *Builder {
+	builder = builder.instance()
+	fields := make([]string, 0, len(filters))
+	for field := range filters {
+		if field != FilterSortKey {
+			fields = append(fields, field)
+		}
+	}
+	// apply filters in a stable order so that the generated sql is deterministic
+	sort.Strings(fields)
+	for _, field := range fields {
+		conditions, ok := filters[field].(map[string]any)
+		if !ok {
+			conditions = map[string]any{string(FilterEq): filters[field]}
+		}
+		operators := make([]string, 0, len(conditions))
+		for operator := range conditions {
+			operators = append(operators, operator)
+		}
+		sort.Strings(operators)
+		for _, operator := range operators {
+			builder = builder.applyFilter(field, FilterOperator(strings.ToLower(operator)), conditions[operator], rules)
+		}
+	}
+	if sorts, ok := filters[FilterSortKey]; ok {
+		for _, field := range filterValues(sorts) {
+			name := strings.TrimPrefix(fmt.Sprint(field), "-")
+			if !rules.Allows(name, FilterSort) {
+				continue
+			}
+			builder = builder.Order(name, strings.HasPrefix(fmt.Sprint(field), "-"))
+		}
+	}
+	return builder
+}
+
+func (builder *Builder) applyFilter(field string, operator FilterOperator, value any, rules FilterRules) *Builder {
+	if operator == FilterSort || !rules.Allows(field, operator) {
+		return builder
+	}
+	switch operator {
+	case FilterEq:
+		return builder.Where(field, value)
+	case FilterLike:
+		return builder.WhereLike(field, "%"+fmt.Sprint(value)+"%")
+	case FilterIn:
+		if values := filterValues(value); len(values) > 0 {
+			return builder.WhereIn(field, values...)
+		}
+	case FilterBetween:
+		if values := filterValues(value); len(values) == 2 {
+			return builder.WhereBetween(field, values[0], values[1])
+		}
+	}
+	return builder
+}
+
+// filterValues converts a slice or a comma separated string to values
+func filterValues(value any) []any {
+	if str, ok := value.(string); ok {
+		values := make([]any, 0)
+		for _, item := range strings.Split(str, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		return values
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []any{value}
+	}
+	values := make([]any, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		values = append(values, rv.Index(i).Interface())
+	}
+	return values
+}
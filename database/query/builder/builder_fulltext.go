@@ -0,0 +1,83 @@
+package builder
+
+import (
+	"strings"
+
+	"github.com/wardonne/gopi/database/exception"
+	queryclause "github.com/wardonne/gopi/database/query/clause"
+	"gorm.io/gorm/clause"
+)
+
+// WhereFullText add a full-text search condition
+//
+//	builder.Table("posts").WhereFullText([]string{"title", "body"}, "database", queryclause.FullTextNatural)
+//	// MySQL: MATCH (`title`,`body`) AGAINST ('database' IN NATURAL LANGUAGE MODE)
+//	// PostgreSQL: to_tsvector(coalesce("title", '') || ' ' || coalesce("body", '')) @@ plainto_tsquery('database')
+//	// SQLite: `posts` MATCH '{title body}: (database)'
+//	builder.Table("posts").WhereFullText([]string{"title"}, "+mysql -oracle", queryclause.FullTextBoolean)
+//
+// # NOTICE: SQLite searches an FTS5 virtual table, the table should be set before searching multiple columns or ordering by relevance
+func (builder *Builder) WhereFullText(columns []string, query string, mode queryclause.FullTextMode) *Builder {
+	builder = builder.instance()
+	builder.db = builder.db.Where(builder.newFullText(columns, query, mode))
+	return builder
+}
+
+// OrWhereFullText add a full-text search condition with OR
+//
+//	builder.Table("posts").Where("status", 1).OrWhereFullText([]string{"title"}, "database", queryclause.FullTextNatural)
+func (builder *Builder) OrWhereFullText(columns []string, query string, mode queryclause.FullTextMode) *Builder {
+	builder = builder.instance()
+	builder.db = builder.db.Or(builder.newFullText(columns, query, mode))
+	return builder
+}
+
+// OrderByRelevance orders by the relevance of the last full-text search condition, the most relevant first
+//
+// the relevance takes precedence over other orders, SQLite requires the table to be set
+//
+//	builder.Table("posts").WhereFullText([]string{"title", "body"}, "database", queryclause.FullTextNatural).OrderByRelevance()
+func (builder *Builder) OrderByRelevance() *Builder {
+	builder = builder.instance()
+	if builder.fullText == nil {
+		exception.ThrowFullTextNotFoundErr()
+	}
+	// SQLite ranks the rows of an FTS5 table by bm25(table)
+	if builder.fullText.Table == "" && queryclause.Dialect(builder.db.Statement) == queryclause.SQLite {
+		exception.ThrowFullTextTableNotFoundErr()
+	}
+	relevance := clause.OrderByColumn{
+		Column:  clause.Column{Name: "?", Raw: true},
+		Reorder: true,
+	}
+	orderBy, _ := builder.db.Statement.Clauses["ORDER BY"].Expression.(clause.OrderBy)
+	// the relevance is added as a raw column, so that bindings of other raw orders are kept
+	builder.rawOrders["?"] = append([][]any{{queryclause.FullTextRelevance{FullText: *builder.fullText}}}, builder.rawOrders["?"]...)
+	builder.db = builder.db.Clauses(clause.OrderBy{Columns: append([]clause.OrderByColumn{relevance}, orderBy.Columns...)})
+	return builder
+}
+
+func (builder *Builder) newFullText(columns []string, query string, mode queryclause.FullTextMode) queryclause.FullText {
+	if len(columns) == 0 {
+		exception.ThrowEmptyFullTextColumnErr()
+	}
+	quoted := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if strings.TrimSpace(column) == "" {
+			exception.ThrowEmptyFullTextColumnErr()
+		}
+		quoted = append(quoted, builder.QuoteField(column))
+	}
+	fullText := queryclause.FullText{
+		Columns: quoted,
+		Query:   query,
+		Mode:    mode,
+	}
+	if table := builder.tableName(); table != "" {
+		fullText.Table = builder.QuoteField(table)
+	} else if len(columns) > 1 && queryclause.Dialect(builder.db.Statement) == queryclause.SQLite {
+		exception.ThrowFullTextTableNotFoundErr()
+	}
+	builder.fullText = &fullText
+	return fullText
+}
@@ -29,3 +29,30 @@ func (builder *Builder) Group(columns ...any) *Builder {
 	}
 	return builder
 }
+
+// GroupByRaw add group by clause by raw sql
+//
+//	builder.GroupByRaw("DATE_FORMAT(created_at, ?)", "%Y-%m") // GROUP BY DATE_FORMAT(created_at, '%Y-%m')
+func (builder *Builder) GroupByRaw(sql string, bindings ...any) *Builder {
+	builder = builder.instance()
+	builder.db = builder.db.Clauses(clause.GroupBy{
+		Columns: []clause.Column{{Name: sql, Raw: true}},
+	})
+	builder.rawGroups.Add(sql, builder.FormatValues(bindings...))
+	return builder
+}
+
+// addRawColumns binds the raw columns of ORDER BY and GROUP BY clauses
+func (builder *Builder) addRawColumns() {
+	if len(builder.rawGroups) > 0 {
+		c := builder.db.Statement.Clauses["GROUP BY"]
+		c.Builder = builder.rawGroups.GroupByBuilder
+		builder.db.Statement.Clauses["GROUP BY"] = c
+	}
+	if len(builder.rawOrders) > 0 {
+		c := builder.db.Statement.Clauses["ORDER BY"]
+		c.Name = "ORDER BY"
+		c.Builder = builder.rawOrders.OrderByBuilder
+		builder.db.Statement.Clauses["ORDER BY"] = c
+	}
+}
@@ -623,6 +623,24 @@ func (builder *Builder) OrHavingNotLike(column string, value string) *Builder {
 	return builder
 }
 
+// HavingRaw add having by raw sql
+//
+//	builder.HavingRaw("SUM(amount) > ?", 100)
+func (builder *Builder) HavingRaw(sql string, values ...any) *Builder {
+	builder = builder.instance()
+	builder.having.Add(clause.Expr{SQL: sql, Vars: builder.FormatValues(values...)})
+	return builder
+}
+
+// OrHavingRaw add or having by raw sql
+//
+//	builder.OrHavingRaw("SUM(amount) > ?", 100)
+func (builder *Builder) OrHavingRaw(sql string, values ...any) *Builder {
+	builder = builder.instance()
+	builder.having.Add(clause.Or(clause.Expr{SQL: sql, Vars: builder.FormatValues(values...)}))
+	return builder
+}
+
 // HavingBuilder merge having from another [Builder] with AND
 func (builder *Builder) HavingBuilder(query *Builder) *Builder {
 	builder = builder.instance()
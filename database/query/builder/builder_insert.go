@@ -2,33 +2,37 @@ package builder
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/wardonne/gopi/database/exception"
+	queryclause "github.com/wardonne/gopi/database/query/clause"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 // FirstOrCreate gets the first record, if not found, create it
 func (builder *Builder) FirstOrCreate(dest any) error {
 	builder.onExecutionFinished = true
-	return builder.DB().FirstOrCreate(dest).Error
+	builder = builder.guessTable(dest)
+	return builder.flushCache(builder.DB().FirstOrCreate(dest).Error)
 }
 
 // FirstOrInit gets the firsst record, if not found, return an inited instance
 func (builder *Builder) FirstOrInit(dest any) error {
 	builder.onExecutionFinished = true
-	return builder.DB().FirstOrInit(dest).Error
+	return builder.guessTable(dest).DB().FirstOrInit(dest).Error
 }
 
 // Create create a new record
 func (builder *Builder) Create(value any) error {
 	builder.onExecutionFinished = true
-	return builder.DB().Create(value).Error
+	return builder.flushCache(builder.DB().Create(value).Error, value)
 }
 
 // CreateInBatches inserts values in batches of batchSize
 func (builder *Builder) CreateInBatches(values []any, batchSize int) error {
 	builder.onExecutionFinished = true
-	return builder.DB().CreateInBatches(values, batchSize).Error
+	return builder.flushCache(builder.DB().CreateInBatches(values, batchSize).Error, values...)
 }
 
 // Upsert insert new records if unique key is conflict then update it
@@ -54,5 +58,79 @@ func (builder *Builder) Upsert(values any, keys []any, updates ...string) error
 	} else {
 		expr.DoUpdates = clause.AssignmentColumns(updates)
 	}
-	return builder.DB().Clauses(expr).Create(values).Error
+	return builder.flushCache(builder.DB().Clauses(expr).Create(values).Error, values)
+}
+
+// InsertOrIgnore inserts values and ignores the records conflicting with unique keys, the number of inserted records is returned
+//
+//	MySQL: INSERT IGNORE INTO ...
+//	PostgreSQL/SQLite: INSERT INTO ... ON CONFLICT DO NOTHING
+func (builder *Builder) InsertOrIgnore(values any) (int64, error) {
+	builder.onExecutionFinished = true
+	db := builder.DB()
+	if queryclause.Dialect(db.Statement) == queryclause.MySQL {
+		db = db.Clauses(clause.Insert{Modifier: "IGNORE"})
+	} else {
+		db = db.Clauses(clause.OnConflict{DoNothing: true})
+	}
+	tx := db.Create(values)
+	return tx.RowsAffected, builder.flushCache(tx.Error, values)
+}
+
+// InsertUsing inserts the records selected by query into columns, the number of inserted records is returned
+//
+//	builder.Table("archived_users").InsertUsing([]string{"id", "name"}, NewBuilder(db).Table("users").Select("id", "name").Where("status", 0))
+//	// INSERT INTO `archived_users` (`id`,`name`) SELECT `id`,`name` FROM `users` WHERE `status` = ?
+func (builder *Builder) InsertUsing(columns []string, query any) (int64, error) {
+	builder.onExecutionFinished = true
+	switch query.(type) {
+	case *Builder, *gorm.DB, Callback:
+	default:
+		exception.ThrowInvalidParamTypeErr("InsertUsing.query", query)
+	}
+	fields := make([]clause.Column, 0, len(columns))
+	for _, column := range columns {
+		fields = append(fields, clause.Column{Name: column})
+	}
+	db := builder.DB()
+	if db.Statement.Table == "" {
+		db.Statement.Table = builder.tableName()
+	}
+	tx := db.Exec("INSERT INTO ? ? ?", clause.Table{Name: clause.CurrentTable}, fields, builder.FormatValue(query))
+	return tx.RowsAffected, builder.flushCache(tx.Error)
+}
+
+// UpdateOrInsert updates the record matching conditions with values, or inserts a new record with conditions and values if none matches
+//
+// conditions are added to current builder, so its conditions, scopes and transaction apply to every step
+//
+//	builder.Table("users").UpdateOrInsert(map[string]any{"email": "john@example.com"}, map[string]any{"name": "John"})
+func (builder *Builder) UpdateOrInsert(conditions map[string]any, values map[string]any) error {
+	builder = builder.instance()
+	columns := make([]string, 0, len(conditions))
+	for column := range conditions {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	for _, column := range columns {
+		builder = builder.Where(column, conditions[column])
+	}
+	exists, err := builder.Exists()
+	if err != nil {
+		return err
+	}
+	if exists {
+		if len(values) == 0 {
+			return nil
+		}
+		return builder.Update(values)
+	}
+	attributes := make(map[string]any, len(conditions)+len(values))
+	for column, value := range conditions {
+		attributes[column] = value
+	}
+	for column, value := range values {
+		attributes[column] = value
+	}
+	return NewBuilder(builder.db.Session(&gorm.Session{NewDB: true})).Table(builder.tableName()).Create(attributes)
 }
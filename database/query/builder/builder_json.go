@@ -1,9 +1,12 @@
 package builder
 
 import (
+	"strings"
+
 	"gorm.io/datatypes"
 	"gorm.io/gorm/clause"
 
+	"github.com/wardonne/gopi/database/exception"
 	queryclause "github.com/wardonne/gopi/database/query/clause"
 )
 
@@ -12,7 +15,7 @@ import (
 //	builder.WhereJSONContains("tags", 1)
 func (builder *Builder) WhereJSONContains(column any, value any) *Builder {
 	builder = builder.instance()
-	builder.db = builder.db.Where(datatypes.JSONArrayQuery(builder.QuoteField(column)).Contains(builder.FormatValue(value)))
+	builder.db = builder.db.Where(queryclause.JSONContains{Column: builder.QuoteField(column), Value: builder.FormatValue(value)})
 	return builder
 }
 
@@ -21,7 +24,7 @@ func (builder *Builder) WhereJSONContains(column any, value any) *Builder {
 //	builder.WhereJSONNOTContains("tags", 1)
 func (builder *Builder) WhereJSONNotContains(column any, value any) *Builder {
 	builder = builder.instance()
-	builder.db = builder.db.Not(datatypes.JSONArrayQuery(builder.QuoteField(column)).Contains(builder.FormatValue(value)))
+	builder.db = builder.db.Not(queryclause.JSONContains{Column: builder.QuoteField(column), Value: builder.FormatValue(value)})
 	return builder
 }
 
@@ -30,7 +33,7 @@ func (builder *Builder) WhereJSONNotContains(column any, value any) *Builder {
 //	builder.OrWhereJSONContains("tags", 1)
 func (builder *Builder) OrWhereJSONContains(column any, value any) *Builder {
 	builder = builder.instance()
-	builder.db = builder.db.Or(datatypes.JSONArrayQuery(builder.QuoteField(column)).Contains(builder.FormatValue(value)))
+	builder.db = builder.db.Or(queryclause.JSONContains{Column: builder.QuoteField(column), Value: builder.FormatValue(value)})
 	return builder
 }
 
@@ -39,7 +42,7 @@ func (builder *Builder) OrWhereJSONContains(column any, value any) *Builder {
 //	builder.OrWhereJSONNotContains("tags", 1)
 func (builder *Builder) OrWhereJSONNotContains(column any, value any) *Builder {
 	builder = builder.instance()
-	builder.db = builder.db.Or(clause.Not(datatypes.JSONArrayQuery(builder.QuoteField(column)).Contains(builder.FormatValue(value))))
+	builder.db = builder.db.Or(clause.Not(queryclause.JSONContains{Column: builder.QuoteField(column), Value: builder.FormatValue(value)}))
 	return builder
 }
 
@@ -104,9 +107,7 @@ func (builder *Builder) OrWhereJSONNotContainsPath(column any, all bool, pathes
 //	builder.WhereJSONOverlaps("tags", "[1,2,3]")
 func (builder *Builder) WhereJSONOverlaps(column any, value string) *Builder {
 	builder = builder.instance()
-	builder.db = builder.db.Where(datatypes.JSONOverlaps(clause.Expr{
-		SQL: builder.QuoteField(column),
-	}, value))
+	builder.db = builder.db.Where(queryclause.JSONOverlaps{Column: builder.QuoteField(column), Value: value})
 	return builder
 }
 
@@ -115,9 +116,7 @@ func (builder *Builder) WhereJSONOverlaps(column any, value string) *Builder {
 //	builder.WhereJSONOverlaps("tags", "[1,2,3]")
 func (builder *Builder) WhereJSONNotOverlaps(column any, value string) *Builder {
 	builder = builder.instance()
-	builder.db = builder.db.Not(datatypes.JSONOverlaps(clause.Expr{
-		SQL: builder.QuoteField(column),
-	}, value))
+	builder.db = builder.db.Not(queryclause.JSONOverlaps{Column: builder.QuoteField(column), Value: value})
 	return builder
 }
 
@@ -126,9 +125,7 @@ func (builder *Builder) WhereJSONNotOverlaps(column any, value string) *Builder
 //	builder.OrWhereJSONOverlaps("tags", "[1,2,3]")
 func (builder *Builder) OrWhereJSONOverlaps(column any, value string) *Builder {
 	builder = builder.instance()
-	builder.db = builder.db.Or(datatypes.JSONOverlaps(clause.Expr{
-		SQL: builder.QuoteField(column),
-	}, value))
+	builder.db = builder.db.Or(queryclause.JSONOverlaps{Column: builder.QuoteField(column), Value: value})
 	return builder
 }
 
@@ -137,9 +134,7 @@ func (builder *Builder) OrWhereJSONOverlaps(column any, value string) *Builder {
 //	builder.OrWhereJSONNotOverlaps("tags", "[1,2,3]")
 func (builder *Builder) OrWhereJSONNotOverlaps(column any, value string) *Builder {
 	builder = builder.instance()
-	builder.db = builder.db.Or(clause.Not(datatypes.JSONOverlaps(clause.Expr{
-		SQL: builder.QuoteField(column),
-	}, value)))
+	builder.db = builder.db.Or(clause.Not(queryclause.JSONOverlaps{Column: builder.QuoteField(column), Value: value}))
 	return builder
 }
 
@@ -184,7 +179,7 @@ func (builder *Builder) OrWhereJSONNotHasKey(column any, keys ...string) *Builde
 //	builder.HavingJSONContains("tags", 1)
 func (builder *Builder) HavingJSONContains(column any, value any) *Builder {
 	builder = builder.instance()
-	builder.having.Add(datatypes.JSONArrayQuery(builder.QuoteField(column)).Contains(builder.FormatValue(value)))
+	builder.having.Add(queryclause.JSONContains{Column: builder.QuoteField(column), Value: builder.FormatValue(value)})
 	return builder
 }
 
@@ -193,7 +188,7 @@ func (builder *Builder) HavingJSONContains(column any, value any) *Builder {
 //	builder.HavingJSONNotContains("tags", 1)
 func (builder *Builder) HavingJSONNotContains(column any, value any) *Builder {
 	builder = builder.instance()
-	builder.having.Add(clause.Not(datatypes.JSONArrayQuery(builder.QuoteField(column)).Contains(builder.FormatValue(value))))
+	builder.having.Add(clause.Not(queryclause.JSONContains{Column: builder.QuoteField(column), Value: builder.FormatValue(value)}))
 	return builder
 }
 
@@ -202,7 +197,7 @@ func (builder *Builder) HavingJSONNotContains(column any, value any) *Builder {
 //	builder.OrHavingJSONContains("tags", 1)
 func (builder *Builder) OrHavingJSONContains(column any, value any) *Builder {
 	builder = builder.instance()
-	builder.having.Add(clause.Or(datatypes.JSONArrayQuery(builder.QuoteField(column)).Contains(builder.FormatValue(value))))
+	builder.having.Add(clause.Or(queryclause.JSONContains{Column: builder.QuoteField(column), Value: builder.FormatValue(value)}))
 	return builder
 }
 
@@ -211,7 +206,7 @@ func (builder *Builder) OrHavingJSONContains(column any, value any) *Builder {
 //	builder.OrHavingJSONNotContains("tags", 1)
 func (builder *Builder) OrHavingJSONNotContains(column any, value any) *Builder {
 	builder = builder.instance()
-	builder.having.Add(clause.Or(clause.Not(datatypes.JSONArrayQuery(builder.QuoteField(column)).Contains(builder.FormatValue(value)))))
+	builder.having.Add(clause.Or(clause.Not(queryclause.JSONContains{Column: builder.QuoteField(column), Value: builder.FormatValue(value)})))
 	return builder
 }
 
@@ -276,9 +271,7 @@ func (builder *Builder) OrHavingJSONNotContainsPath(column any, all bool, pathes
 //	builder.HavingJSONOverlaps("tags", "[1,2,3]")
 func (builder *Builder) HavingJSONOverlaps(column any, value string) *Builder {
 	builder = builder.instance()
-	builder.having.Add(datatypes.JSONOverlaps(clause.Expr{
-		SQL: builder.QuoteField(column),
-	}, value))
+	builder.having.Add(queryclause.JSONOverlaps{Column: builder.QuoteField(column), Value: value})
 	return builder
 }
 
@@ -287,9 +280,7 @@ func (builder *Builder) HavingJSONOverlaps(column any, value string) *Builder {
 //	builder.HavingJSONNotOverlaps("tags", "[1,2,3]")
 func (builder *Builder) HavingJSONNotOverlaps(column any, value string) *Builder {
 	builder = builder.instance()
-	builder.having.Add(clause.Not(datatypes.JSONOverlaps(clause.Expr{
-		SQL: builder.QuoteField(column),
-	}, value)))
+	builder.having.Add(clause.Not(queryclause.JSONOverlaps{Column: builder.QuoteField(column), Value: value}))
 	return builder
 }
 
@@ -298,9 +289,7 @@ func (builder *Builder) HavingJSONNotOverlaps(column any, value string) *Builder
 //	builder.OrHavingJSONOverlaps("tags", "[1,2,3]")
 func (builder *Builder) OrHavingJSONOverlaps(column any, value string) *Builder {
 	builder = builder.instance()
-	builder.having.Add(clause.Or(datatypes.JSONOverlaps(clause.Expr{
-		SQL: builder.QuoteField(column),
-	}, value)))
+	builder.having.Add(clause.Or(queryclause.JSONOverlaps{Column: builder.QuoteField(column), Value: value}))
 	return builder
 }
 
@@ -309,9 +298,7 @@ func (builder *Builder) OrHavingJSONOverlaps(column any, value string) *Builder
 //	builder.OrHavingJSONNotOverlaps("tags", "[1,2,3]")
 func (builder *Builder) OrHavingJSONNotOverlaps(column any, value string) *Builder {
 	builder = builder.instance()
-	builder.having.Add(clause.Or(clause.Not(datatypes.JSONOverlaps(clause.Expr{
-		SQL: builder.QuoteField(column),
-	}, value))))
+	builder.having.Add(clause.Or(clause.Not(queryclause.JSONOverlaps{Column: builder.QuoteField(column), Value: value})))
 	return builder
 }
 
@@ -350,3 +337,95 @@ func (builder *Builder) OrHavingJSONNotHasKey(column any, keys ...string) *Build
 	builder.having.Add(clause.Or(clause.Not(datatypes.JSONQuery(builder.QuoteField(column)).HasKey(keys...))))
 	return builder
 }
+
+var jsonOperators = map[string]bool{
+	"=": true, "<>": true, "!=": true, ">": true, ">=": true, "<": true, "<=": true,
+	"like": true, "not like": true,
+}
+
+func (builder *Builder) jsonOperator(method, operator string) string {
+	operator = strings.ToLower(strings.TrimSpace(operator))
+	if !jsonOperators[operator] {
+		exception.ThrowInvalidParamTypeErr(method, operator)
+	}
+	return strings.ToUpper(operator)
+}
+
+// WhereJSON compares the value at the json path, keys of the path are separated by `->`
+//
+//	builder.WhereJSON("meta->address->city", "=", "Paris")
+//	// MySQL: JSON_UNQUOTE(JSON_EXTRACT(`meta`, '$."address"."city"')) = 'Paris'
+//	// PostgreSQL: ("meta"::jsonb #>> '{address,city}') = 'Paris'
+//	// SQLite: json_extract(`meta`, '$."address"."city"') = 'Paris'
+//	builder.WhereJSON("meta->age", ">", 18)
+func (builder *Builder) WhereJSON(path string, operator string, value any) *Builder {
+	builder = builder.instance()
+	builder.db = builder.db.Where(builder.jsonCompare("WhereJSON", path, operator, value))
+	return builder
+}
+
+// OrWhereJSON where OR compares the value at the json path
+//
+//	builder.OrWhereJSON("meta->address->city", "=", "Paris")
+func (builder *Builder) OrWhereJSON(path string, operator string, value any) *Builder {
+	builder = builder.instance()
+	builder.db = builder.db.Or(builder.jsonCompare("OrWhereJSON", path, operator, value))
+	return builder
+}
+
+// WhereJSONLength compares the length of the json array at the json path
+//
+//	builder.WhereJSONLength("tags", ">", 2)
+//	builder.WhereJSONLength("meta->roles", "=", 0)
+func (builder *Builder) WhereJSONLength(path string, operator string, value int) *Builder {
+	builder = builder.instance()
+	builder.db = builder.db.Where(builder.jsonLength("WhereJSONLength", path, operator, value))
+	return builder
+}
+
+// OrWhereJSONLength where OR compares the length of the json array at the json path
+//
+//	builder.OrWhereJSONLength("tags", ">", 2)
+func (builder *Builder) OrWhereJSONLength(path string, operator string, value int) *Builder {
+	builder = builder.instance()
+	builder.db = builder.db.Or(builder.jsonLength("OrWhereJSONLength", path, operator, value))
+	return builder
+}
+
+// UpdateJSON updates the value at the path of the json column of all matched records
+//
+// keys of the path are separated by `->` or `.`
+//
+//	builder.Table("users").Where("id", 1).UpdateJSON("meta", "address->city", "Paris")
+//	builder.Table("users").Where("id", 1).UpdateJSON("meta", "roles", []string{"admin"})
+func (builder *Builder) UpdateJSON(column string, path string, value any) error {
+	builder.onExecutionFinished = true
+	keys := strings.FieldsFunc(strings.ReplaceAll(path, "->", "."), func(r rune) bool {
+		return r == '.'
+	})
+	return builder.flushCache(builder.DB().Update(column, queryclause.JSONSet{
+		Column: builder.QuoteField(column),
+		Keys:   keys,
+		Value:  value,
+	}).Error)
+}
+
+func (builder *Builder) jsonCompare(method, path, operator string, value any) queryclause.JSONCompare {
+	column, keys := queryclause.JSONPath(path)
+	return queryclause.JSONCompare{
+		Column:   builder.QuoteField(column),
+		Keys:     keys,
+		Operator: builder.jsonOperator(method, operator),
+		Value:    builder.FormatValue(value),
+	}
+}
+
+func (builder *Builder) jsonLength(method, path, operator string, value int) queryclause.JSONLength {
+	column, keys := queryclause.JSONPath(path)
+	return queryclause.JSONLength{
+		Column:   builder.QuoteField(column),
+		Keys:     keys,
+		Operator: builder.jsonOperator(method, operator),
+		Value:    value,
+	}
+}
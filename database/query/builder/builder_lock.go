@@ -0,0 +1,57 @@
+package builder
+
+import (
+	"github.com/wardonne/gopi/database/exception"
+	queryclause "github.com/wardonne/gopi/database/query/clause"
+)
+
+// LockForUpdate locks the selected rows for update
+//
+// # NOTICE: locks can only be used in a transaction started by [Builder.Begin] or [Builder.Transaction],
+// otherwise the query returns [exception.LockWithoutTransactionErr]
+//
+//	builder.Begin()
+//	builder.Table("products").Where("id", 1).LockForUpdate().First(&product) // SELECT ... FOR UPDATE
+func (builder *Builder) LockForUpdate() *Builder {
+	builder = builder.instance()
+	builder.lock.Strength = queryclause.LockForUpdate
+	builder.db = builder.db.Clauses(builder.lock)
+	return builder
+}
+
+// SharedLock locks the selected rows in share mode
+//
+//	builder.Table("products").Where("id", 1).SharedLock().First(&product) // SELECT ... FOR SHARE
+func (builder *Builder) SharedLock() *Builder {
+	builder = builder.instance()
+	builder.lock.Strength = queryclause.LockForShare
+	builder.db = builder.db.Clauses(builder.lock)
+	return builder
+}
+
+// SkipLocked skips the rows locked by others, it locks for update if no lock is set
+//
+//	builder.Table("jobs").Where("status", 0).LockForUpdate().SkipLocked().First(&job) // SELECT ... FOR UPDATE SKIP LOCKED
+func (builder *Builder) SkipLocked() *Builder {
+	builder = builder.instance()
+	builder.lock.Options = queryclause.LockSkipLocked
+	builder.db = builder.db.Clauses(builder.lock)
+	return builder
+}
+
+// NoWait fails immediately if the rows are locked by others, it locks for update if no lock is set
+//
+//	builder.Table("products").Where("id", 1).LockForUpdate().NoWait().First(&product) // SELECT ... FOR UPDATE NOWAIT
+func (builder *Builder) NoWait() *Builder {
+	builder = builder.instance()
+	builder.lock.Options = queryclause.LockNoWait
+	builder.db = builder.db.Clauses(builder.lock)
+	return builder
+}
+
+// checkLock refuses locks outside a transaction
+func (builder *Builder) checkLock() {
+	if _, ok := builder.db.Statement.Clauses["FOR"]; ok && !builder.onTransaction {
+		builder.AddError(exception.NewLockWithoutTransactionErr())
+	}
+}
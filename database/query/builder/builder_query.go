@@ -1,6 +1,8 @@
 package builder
 
 import (
+	"fmt"
+
 	"gorm.io/gorm"
 )
 
@@ -10,32 +12,38 @@ func (builder *Builder) Exists() (bool, error) {
 	var dest = new(struct {
 		Result bool
 	})
-	err := builder.conn.Raw("SELECT EXISTS (?) AS `result`", builder.DB()).Scan(dest).Error
+	db := builder.DB()
+	sql := fmt.Sprintf("SELECT EXISTS (?) AS %s", builder.QuoteField("result"))
+	err := db.Session(&gorm.Session{NewDB: true}).Raw(sql, db).Scan(dest).Error
 	return dest.Result, err
 }
 
 // Take gets the first matched record without specific order
 func (builder *Builder) Take(dest any) error {
 	builder.onExecutionFinished = true
-	return builder.DB().Take(dest).Error
+	return builder.guessTable(dest).DB().Take(dest).Error
 }
 
 // First gets the first matched record order by primary key asc
 func (builder *Builder) First(dest any) error {
 	builder.onExecutionFinished = true
-	return builder.DB().First(dest).Error
+	return builder.guessTable(dest).cached(dest, func(tx *gorm.DB) *gorm.DB {
+		return tx.First(dest)
+	})
 }
 
 // Last gets the last matched record order by primary key desc
 func (builder *Builder) Last(dest any) error {
 	builder.onExecutionFinished = true
-	return builder.DB().Last(dest).Error
+	return builder.guessTable(dest).DB().Last(dest).Error
 }
 
 // Find find all matched records
 func (builder *Builder) Find(dest any) error {
 	builder.onExecutionFinished = true
-	return builder.DB().Find(dest).Error
+	return builder.guessTable(dest).cached(dest, func(tx *gorm.DB) *gorm.DB {
+		return tx.Find(dest)
+	})
 }
 
 // Pluck gets single column from results
@@ -43,19 +51,22 @@ func (builder *Builder) Pluck(column any, dest any) error {
 	builder.selects.Clear()
 	builder = builder.Select(column)
 	builder.onExecutionFinished = true
-	return builder.DB().Pluck(builder.selects.First().Name, dest).Error
+	name := builder.selects.First().Column.Name
+	return builder.cached(dest, func(tx *gorm.DB) *gorm.DB {
+		return tx.Pluck(name, dest)
+	})
 }
 
 // Chunk find all matched records in batches of batchSize
 func (builder *Builder) Chunk(dest any, batchSize int, callback func(tx *gorm.DB, batch int) error) error {
 	builder.onExecutionFinished = true
-	return builder.DB().FindInBatches(dest, batchSize, callback).Error
+	return builder.guessTable(dest).DB().FindInBatches(dest, batchSize, callback).Error
 }
 
 // Cursor iteration
 func (builder *Builder) Cursor(dest any, callback func() error) error {
 	builder.onExecutionFinished = true
-	rows, err := builder.DB().Rows()
+	rows, err := builder.guessTable(dest).DB().Rows()
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,77 @@
 package builder
 
-import "gorm.io/gorm"
+import (
+	"sync"
+
+	"github.com/wardonne/gopi/database/exception"
+	"github.com/wardonne/gopi/support/maps"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LocalScope named scope which can be called by [Builder.Scope]
+type LocalScope = func(builder *Builder, args ...any) *Builder
+
+var (
+	scopeMu      sync.Mutex
+	globalScopes = maps.NewSyncHashMap[string, *maps.SyncLinkedHashMap[string, Clause]]()
+	localScopes  = maps.NewSyncHashMap[string, *maps.SyncHashMap[string, LocalScope]]()
+)
+
+// RegisterGlobalScope registers a named scope which will be applied to every query of the table
+//
+// model can be a table name, a [clause.Table] or a model instance
+//
+//	RegisterGlobalScope("posts", "published", func(builder *Builder) *Builder {
+//		return builder.Where("published", true)
+//	})
+//	RegisterGlobalScope(new(Post), "published", func(builder *Builder) *Builder {
+//		return builder.Where("published", true)
+//	})
+func RegisterGlobalScope(model any, name string, scope Clause) {
+	table := TableName(model)
+	if table == "" {
+		exception.ThrowInvalidParamTypeErr("RegisterGlobalScope", model)
+	}
+	scopeMu.Lock()
+	defer scopeMu.Unlock()
+	if !globalScopes.ContainsKey(table) {
+		globalScopes.Set(table, maps.NewSyncLinkedHashMap[string, Clause]())
+	}
+	globalScopes.Get(table).Set(name, scope)
+}
+
+// RemoveGlobalScope removes a registered global scope of the table
+func RemoveGlobalScope(model any, name string) {
+	if scopes := globalScopes.Get(TableName(model)); scopes != nil {
+		scopes.Remove(name)
+	}
+}
+
+// RegisterLocalScope registers a named scope of the table which can be called by [Builder.Scope]
+//
+//	RegisterLocalScope(new(User), "active", func(builder *Builder, args ...any) *Builder {
+//		return builder.Where("status", 1)
+//	})
+func RegisterLocalScope(model any, name string, scope LocalScope) {
+	table := TableName(model)
+	if table == "" {
+		exception.ThrowInvalidParamTypeErr("RegisterLocalScope", model)
+	}
+	scopeMu.Lock()
+	defer scopeMu.Unlock()
+	if !localScopes.ContainsKey(table) {
+		localScopes.Set(table, maps.NewSyncHashMap[string, LocalScope]())
+	}
+	localScopes.Get(table).Set(name, scope)
+}
+
+// RemoveLocalScope removes a registered local scope of the table
+func RemoveLocalScope(model any, name string) {
+	if scopes := localScopes.Get(TableName(model)); scopes != nil {
+		scopes.Remove(name)
+	}
+}
 
 // Scopes add scopes
 func (builder *Builder) Scopes(scopes ...func(tx *gorm.DB) *gorm.DB) *Builder {
@@ -8,3 +79,109 @@ func (builder *Builder) Scopes(scopes ...func(tx *gorm.DB) *gorm.DB) *Builder {
 	builder.db = builder.db.Scopes(scopes...)
 	return builder
 }
+
+// Scope applies a registered local scope of current table
+//
+// # NOTICE: table or model should be set before calling Scope
+//
+//	builder.Table("users").Scope("active")
+//	builder.Model(new(User)).Scope("olderThan", 18)
+func (builder *Builder) Scope(name string, args ...any) *Builder {
+	builder = builder.instance()
+	table := builder.tableName()
+	var scope LocalScope
+	if scopes := localScopes.Get(table); scopes != nil {
+		scope = scopes.Get(name)
+	}
+	if scope == nil {
+		exception.ThrowScopeNotFoundErr(table, name)
+	}
+	return scope(builder, args...)
+}
+
+// WithoutGlobalScope removes registered global scopes by names from current query
+//
+//	builder.Table("posts").WithoutGlobalScope("published")
+func (builder *Builder) WithoutGlobalScope(names ...string) *Builder {
+	builder = builder.instance()
+	for _, name := range names {
+		builder.excludedScopes[name] = true
+	}
+	return builder
+}
+
+// WithoutGlobalScopes removes all registered global scopes from current query
+func (builder *Builder) WithoutGlobalScopes() *Builder {
+	builder = builder.instance()
+	builder.withoutGlobalScopes = true
+	return builder
+}
+
+func (builder *Builder) tableName() string {
+	if builder.table != "" {
+		return builder.table
+	}
+	return TableName(builder.db.Statement.Model)
+}
+
+// guessTable resolves the table from the destination when neither table nor model is set
+func (builder *Builder) guessTable(dest any) *Builder {
+	if builder.table == "" && builder.db.Statement.Model == nil {
+		builder.table = TableName(dest)
+	}
+	return builder
+}
+
+func (builder *Builder) applyGlobalScopes() {
+	if builder.scopesApplied || builder.withoutGlobalScopes {
+		return
+	}
+	builder.scopesApplied = true
+	registered := globalScopes.Get(builder.tableName())
+	if registered == nil {
+		return
+	}
+	scopes := make([]Clause, 0, registered.Count())
+	for _, entry := range registered.Entries() {
+		if !builder.excludedScopes[entry.Key] {
+			scopes = append(scopes, entry.Value)
+		}
+	}
+	builder.applyScopes(scopes...)
+}
+
+// applyScopes applies the scopes to the where conditions of the building statement
+func (builder *Builder) applyScopes(scopes ...Clause) {
+	if len(scopes) == 0 {
+		return
+	}
+	builder.groupWhereConditions()
+	// scopes are applied while executing, keep the builder in building state
+	// so that a transaction builder won't be replaced by a new instance
+	finished := builder.onExecutionFinished
+	builder.onExecutionFinished = false
+	for _, scope := range scopes {
+		scope(builder)
+	}
+	builder.onExecutionFinished = finished
+}
+
+// groupWhereConditions wraps the existing conditions in parentheses when any OR condition exists,
+// so that conditions appended later won't change their precedence
+func (builder *Builder) groupWhereConditions() {
+	c, ok := builder.db.Statement.Clauses["WHERE"]
+	if !ok {
+		return
+	}
+	where, ok := c.Expression.(clause.Where)
+	if !ok || len(where.Exprs) < 2 {
+		return
+	}
+	for _, expr := range where.Exprs {
+		if _, ok := expr.(clause.OrConditions); ok {
+			c.Expression = clause.Where{Exprs: []clause.Expression{clause.AndConditions{Exprs: where.Exprs}}}
+			builder.db.Statement.Clauses["WHERE"] = c
+			return
+		}
+	}
+}
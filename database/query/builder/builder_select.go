@@ -1,6 +1,9 @@
 package builder
 
 import (
+	"strings"
+
+	"github.com/wardonne/gopi/database/exception"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -32,30 +35,102 @@ func (builder *Builder) Select(columns ...any) *Builder {
 				value.Build(stmt)
 				return tx
 			})
-			builder.selects.Add(clause.Column{
+			builder.selects.Add(selectColumn{Column: clause.Column{
 				Name: rawCol,
 				Raw:  true,
-			})
+			}})
 		case clause.NamedExpr:
 			rawCol := builder.conn.Clauses().ToSQL(func(tx *gorm.DB) *gorm.DB {
 				stmt := tx.Statement
 				value.Build(stmt)
 				return tx
 			})
-			builder.selects.Add(clause.Column{
+			builder.selects.Add(selectColumn{Column: clause.Column{
 				Name: rawCol,
 				Raw:  true,
-			})
+			}})
 		default:
-			builder.selects.Add(clause.Column{
+			builder.selects.Add(selectColumn{Column: clause.Column{
 				Name: builder.QuoteField(column),
 				Raw:  true,
-			})
+			}})
 		}
 	}
 	return builder
 }
 
+// AddSelect appends columns to the select clause, works the same as [Builder.Select]
+//
+//	builder.Select("id").AddSelect("name") // SELECT id, name
+func (builder *Builder) AddSelect(columns ...any) *Builder {
+	return builder.Select(columns...)
+}
+
+// SelectSub appends a subquery column with alias to the select clause, bindings of the subquery are kept
+//
+//	builder.Table("users").SelectSub(
+//		NewBuilder(db).Table("orders").Select(clause.Expr{SQL: "COUNT(*)"}).WhereRaw("orders.user_id = users.id").Where("status", 1),
+//		"paid_orders",
+//	) // SELECT (SELECT COUNT(*) FROM orders WHERE orders.user_id = users.id AND status = ?) AS paid_orders FROM users
+func (builder *Builder) SelectSub(query any, alias string) *Builder {
+	builder = builder.instance()
+	switch query.(type) {
+	case *Builder, *gorm.DB, Callback:
+	default:
+		exception.ThrowInvalidParamTypeErr("SelectSub", query)
+	}
+	if strings.TrimSpace(alias) == "" {
+		exception.ThrowEmptySubQueryAliasErr()
+	}
+	builder.selects.Add(selectColumn{
+		Column: clause.Column{Name: "(?) AS " + builder.QuoteField(alias), Raw: true},
+		Vars:   []any{builder.FormatValue(query)},
+	})
+	return builder
+}
+
+// SelectRaw appends a raw column with bindings to the select clause
+//
+//	builder.SelectRaw("price * ? AS price_with_tax", 1.1)
+func (builder *Builder) SelectRaw(sql string, bindings ...any) *Builder {
+	builder = builder.instance()
+	builder.selects.Add(selectColumn{
+		Column: clause.Column{Name: sql, Raw: true},
+		Vars:   builder.FormatValues(bindings...),
+	})
+	return builder
+}
+
+// selectClause returns the select clause, columns with bindings are built as an expression
+func (builder *Builder) selectClause() clause.Select {
+	selects := builder.selects.ToArray()
+	columns := make([]clause.Column, 0, len(selects))
+	bound := false
+	for _, column := range selects {
+		columns = append(columns, column.Column)
+		bound = bound || len(column.Vars) > 0
+	}
+	selectClause := clause.Select{
+		Distinct: builder.distinct,
+		Columns:  columns,
+	}
+	if !bound {
+		return selectClause
+	}
+	placeholders := make([]string, 0, len(selects))
+	vars := make([]any, 0, len(selects))
+	for _, column := range selects {
+		placeholders = append(placeholders, "?")
+		if len(column.Vars) > 0 {
+			vars = append(vars, clause.Expr{SQL: column.Column.Name, Vars: column.Vars})
+		} else {
+			vars = append(vars, column.Column)
+		}
+	}
+	selectClause.Expression = clause.Expr{SQL: strings.Join(placeholders, ","), Vars: vars}
+	return selectClause
+}
+
 // Distinct distinct
 //
 //	builder.Distinct()
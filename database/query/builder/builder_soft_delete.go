@@ -0,0 +1,166 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wardonne/gopi/database/exception"
+	"github.com/wardonne/gopi/support/maps"
+	"gorm.io/gorm/clause"
+)
+
+// SoftDeletingScope the name of the global scope registered by [RegisterSoftDeletes]
+const SoftDeletingScope = "softDeletes"
+
+// DefaultDeletedAtColumn default column of soft deletes
+const DefaultDeletedAtColumn = "deleted_at"
+
+var softDeletes = maps.NewSyncHashMap[string, string]()
+
+// RegisterSoftDeletes enables soft deletes of the table
+//
+// deleted rows are filtered by `deleted_at IS NULL` in every query, and [Builder.Delete]
+// updates the deleted column instead of deleting rows.
+//
+//	RegisterSoftDeletes(new(User))
+//	RegisterSoftDeletes("users", "removed_at")
+func RegisterSoftDeletes(model any, column ...string) {
+	table := TableName(model)
+	if table == "" {
+		exception.ThrowInvalidParamTypeErr("RegisterSoftDeletes", model)
+	}
+	deletedAt := DefaultDeletedAtColumn
+	if len(column) > 0 && column[0] != "" {
+		deletedAt = column[0]
+	}
+	softDeletes.Set(table, deletedAt)
+	RegisterGlobalScope(table, SoftDeletingScope, func(builder *Builder) *Builder {
+		builder.applySoftDeletesToJoins()
+		return builder.WhereNull(builder.QualifyColumn(deletedAt))
+	})
+}
+
+// RemoveSoftDeletes disables soft deletes of the table
+func RemoveSoftDeletes(model any) {
+	table := TableName(model)
+	softDeletes.Remove(table)
+	RemoveGlobalScope(table, SoftDeletingScope)
+}
+
+// WithTrashed includes soft deleted records
+func (builder *Builder) WithTrashed() *Builder {
+	return builder.WithoutGlobalScope(SoftDeletingScope)
+}
+
+// OnlyTrashed only queries soft deleted records
+//
+//	builder.Table("users").OnlyTrashed() // users.deleted_at IS NOT NULL
+func (builder *Builder) OnlyTrashed() *Builder {
+	builder = builder.WithTrashed()
+	return builder.WhereNotNull(builder.QualifyColumn(builder.mustDeletedAtColumn()))
+}
+
+// Restore restores all matched soft deleted records
+func (builder *Builder) Restore() error {
+	column := builder.mustDeletedAtColumn()
+	builder = builder.instance()
+	defer builder.excludeSoftDeletingScope()()
+	builder.onExecutionFinished = true
+	return builder.flushCache(builder.DB().Updates(map[string]any{column: nil}).Error)
+}
+
+// ForceDelete deletes all matched records permanently even if soft deletes is enabled
+func (builder *Builder) ForceDelete() error {
+	builder = builder.instance()
+	defer builder.excludeSoftDeletingScope()()
+	builder.onExecutionFinished = true
+	return builder.flushCache(builder.DB().Unscoped().Delete(nil).Error)
+}
+
+// excludeSoftDeletingScope excludes the soft deleting scope for one statement,
+// the returned function reverts it so that the builder can be reused
+func (builder *Builder) excludeSoftDeletingScope() func() {
+	excluded, applied := builder.excludedScopes[SoftDeletingScope], builder.scopesApplied
+	builder.excludedScopes[SoftDeletingScope] = true
+	return func() {
+		if excluded {
+			return
+		}
+		delete(builder.excludedScopes, SoftDeletingScope)
+		// global scopes are applied once per builder, add the excluded one back for later statements
+		if !applied && builder.scopesApplied && !builder.withoutGlobalScopes {
+			if scopes := globalScopes.Get(builder.tableName()); scopes != nil && scopes.ContainsKey(SoftDeletingScope) {
+				builder.applyScopes(scopes.Get(SoftDeletingScope))
+			}
+		}
+	}
+}
+
+func (builder *Builder) deletedAtColumn() (string, bool) {
+	table := builder.tableName()
+	if !softDeletes.ContainsKey(table) {
+		return "", false
+	}
+	return softDeletes.Get(table), true
+}
+
+func (builder *Builder) mustDeletedAtColumn() string {
+	column, ok := builder.deletedAtColumn()
+	if !ok {
+		exception.ThrowSoftDeletesNotEnabledErr(builder.tableName())
+	}
+	return column
+}
+
+// QualifyColumn prefixes the column with the alias or name of current table
+//
+//	builder.Table("users").QualifyColumn("id") // users.id
+func (builder *Builder) QualifyColumn(column string) string {
+	table := builder.tableAlias
+	if table == "" {
+		table = builder.tableName()
+	}
+	if table == "" {
+		return column
+	}
+	return table + "." + column
+}
+
+// applySoftDeletesToJoins filters soft deleted records of joined tables in their ON conditions
+func (builder *Builder) applySoftDeletesToJoins() {
+	builder.joins.Map(func(join clause.Join) clause.Join {
+		table, alias := parseJoinTable(join.Table)
+		if table == "" || !softDeletes.ContainsKey(table) {
+			return join
+		}
+		exprs := make([]clause.Expression, 0, len(join.ON.Exprs)+1)
+		exprs = append(exprs, join.ON.Exprs...)
+		exprs = append(exprs, clause.Expr{
+			SQL: fmt.Sprintf("%s IS NULL", builder.QuoteField(alias+"."+softDeletes.Get(table))),
+		})
+		join.ON = clause.Where{Exprs: exprs}
+		return join
+	})
+}
+
+// parseJoinTable parses table name and alias from `table`, `table alias` or `table AS alias`
+func parseJoinTable(table clause.Table) (string, string) {
+	if table.Name == "" || strings.HasPrefix(table.Name, "(") {
+		return "", ""
+	}
+	fields := strings.Fields(table.Name)
+	name := strings.Trim(fields[0], "`\"")
+	alias := name
+	switch {
+	case len(fields) == 2:
+		alias = strings.Trim(fields[1], "`\"")
+	case len(fields) == 3 && strings.EqualFold(fields[1], "AS"):
+		alias = strings.Trim(fields[2], "`\"")
+	case len(fields) > 1:
+		return "", ""
+	}
+	if table.Alias != "" {
+		alias = table.Alias
+	}
+	return name, alias
+}
@@ -79,7 +79,15 @@ func (builder *Builder) Table(table any, values ...any) *Builder {
 		vars = append(vars, values...)
 		builder.db = builder.db.Table(value.SQL, builder.FormatValues(vars...)...)
 	default:
+		builder.table, builder.tableAlias = TableName(value), ""
+		if v, ok := value.(clause.Table); ok {
+			builder.tableAlias = v.Alias
+		}
 		builder.db = builder.db.Table(BuildTable(builder.db, value, "", values...))
+		if builder.db.Statement.Table == "" {
+			// gorm can't parse the table name from a quoted table, keep it for callbacks and plugins
+			builder.db.Statement.Table = builder.table
+		}
 	}
 	return builder
 }
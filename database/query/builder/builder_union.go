@@ -0,0 +1,69 @@
+package builder
+
+import (
+	"github.com/wardonne/gopi/database/exception"
+	queryclause "github.com/wardonne/gopi/database/query/clause"
+	"gorm.io/gorm"
+)
+
+// Union combines the result of another query and removes duplicate rows
+//
+// ORDER BY and LIMIT of current builder apply to the combined result
+//
+//	builder.Table("users").Where("status", 1).Union(NewBuilder(db).Table("admins")).OrderDesc("id").Limit(10)
+//	// SELECT * FROM `users` WHERE `status` = 1 UNION SELECT * FROM `admins` ORDER BY `id` DESC LIMIT 10
+func (builder *Builder) Union(query any) *Builder {
+	return builder.setOperation("Union", queryclause.Union, query)
+}
+
+// UnionAll combines the result of another query and keeps duplicate rows
+//
+//	builder.Table("users").UnionAll(NewBuilder(db).Table("admins"))
+func (builder *Builder) UnionAll(query any) *Builder {
+	return builder.setOperation("UnionAll", queryclause.UnionAll, query)
+}
+
+// Intersect returns the rows which are also in the result of another query
+//
+//	builder.Table("users").Intersect(NewBuilder(db).Table("admins"))
+func (builder *Builder) Intersect(query any) *Builder {
+	return builder.setOperation("Intersect", queryclause.Intersect, query)
+}
+
+// Except returns the rows which are not in the result of another query
+//
+//	builder.Table("users").Except(NewBuilder(db).Table("admins"))
+func (builder *Builder) Except(query any) *Builder {
+	return builder.setOperation("Except", queryclause.Except, query)
+}
+
+func (builder *Builder) setOperation(method string, operator queryclause.SetOperator, query any) *Builder {
+	builder = builder.instance()
+	db, ok := builder.FormatValue(query).(*gorm.DB)
+	if !ok {
+		exception.ThrowInvalidParamTypeErr(method, query)
+	}
+	builder.setOperations.Add(queryclause.SetOperation{
+		Operator: operator,
+		Query:    db,
+	})
+	return builder
+}
+
+// addSetOperations builds set operations right before ORDER BY clause
+func (builder *Builder) addSetOperations() {
+	if builder.setOperations.IsEmpty() {
+		return
+	}
+	c := builder.db.Statement.Clauses["ORDER BY"]
+	c.Name = "ORDER BY"
+	operations := queryclause.SetOperations(builder.setOperations.ToArray())
+	// the clause builder is kept in the statement, wrap the raw orders instead of it
+	// so that building again won't repeat the set operations
+	if len(builder.rawOrders) > 0 {
+		c.Builder = operations.OrderedBy(builder.rawOrders.OrderByBuilder)
+	} else {
+		c.Builder = operations.ClauseBuilder
+	}
+	builder.db.Statement.Clauses["ORDER BY"] = c
+}
@@ -1,7 +1,67 @@
 package builder
 
+import (
+	queryclause "github.com/wardonne/gopi/database/query/clause"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
 // Update update all matched records
 func (builder *Builder) Update(values any) error {
 	builder.onExecutionFinished = true
-	return builder.DB().Updates(values).Error
+	return builder.flushCache(builder.DB().Updates(values).Error)
+}
+
+// Increment increases the column of all matched records by amount, extra columns are updated at the same time
+//
+//	builder.Table("posts").Where("id", 1).Increment("views", 1) // UPDATE `posts` SET `views`=`views` + 1 WHERE `id` = 1
+//	builder.Table("posts").Where("id", 1).Increment("views", 1, map[string]any{"viewed_at": time.Now()})
+func (builder *Builder) Increment(column string, amount any, extra ...map[string]any) error {
+	return builder.Update(builder.stepValues(column, "+", amount, extra...))
+}
+
+// Decrement decreases the column of all matched records by amount, extra columns are updated at the same time
+//
+//	builder.Table("products").Where("id", 1).Decrement("stock", 2) // UPDATE `products` SET `stock`=`stock` - 2 WHERE `id` = 1
+func (builder *Builder) Decrement(column string, amount any, extra ...map[string]any) error {
+	return builder.Update(builder.stepValues(column, "-", amount, extra...))
+}
+
+func (builder *Builder) stepValues(column string, operator string, amount any, extra ...map[string]any) map[string]any {
+	values := make(map[string]any)
+	for _, attributes := range extra {
+		for key, value := range attributes {
+			values[key] = value
+		}
+	}
+	values[column] = gorm.Expr("? "+operator+" ?", clause.Column{Name: column}, amount)
+	return values
+}
+
+// UpdateFrom updates all matched records with the joined tables
+//
+//	builder.Table("users").
+//		InnerJoin("orders", "orders.user_id = users.id").
+//		Where("orders.status", "paid").
+//		UpdateFrom(map[string]any{"users.level": 2})
+//	// MySQL: UPDATE `users` INNER JOIN orders ON orders.user_id = users.id SET `users`.`level`=? WHERE `orders`.`status` = ?
+//	// PostgreSQL: UPDATE "users" SET "level"=$1 FROM orders WHERE orders.user_id = users.id AND "orders"."status" = $2
+//
+// It's the same as [Builder.Update] if there is no join, see [queryclause.JoinedUpdate]
+func (builder *Builder) UpdateFrom(values map[string]any) error {
+	if builder.joins.Count() == 0 {
+		return builder.Update(values)
+	}
+	builder.onExecutionFinished = true
+	db := builder.DB()
+	where, _ := db.Statement.Clauses["WHERE"].Expression.(clause.Where)
+	if db.Statement.Table == "" {
+		db.Statement.Table = builder.tableName()
+	}
+	tx := db.Exec("?", queryclause.JoinedUpdate{
+		Joins: builder.joins.ToArray(),
+		Set:   clause.Assignments(values),
+		Where: where,
+	})
+	return builder.flushCache(tx.Error)
 }
@@ -0,0 +1,41 @@
+package builder
+
+import (
+	queryclause "github.com/wardonne/gopi/database/query/clause"
+	"gorm.io/gorm/clause"
+)
+
+// SelectWindow adds a window function column
+//
+//	builder.Table("employees").Select("*").SelectWindow("rank",
+//		queryclause.RowNumber(),
+//		[]string{"department_id"},
+//		[]clause.OrderByColumn{{Column: clause.Column{Name: "salary"}, Desc: true}},
+//		nil,
+//	)
+//	// SELECT *,ROW_NUMBER() OVER (PARTITION BY `department_id` ORDER BY `salary` DESC) AS `rank` FROM `employees`
+//
+//	builder.Table("orders").Select("id").SelectWindow("running_total",
+//		queryclause.Sum("amount"),
+//		nil,
+//		[]clause.OrderByColumn{{Column: clause.Column{Name: "created_at"}}},
+//		&queryclause.Frame{Start: queryclause.UnboundedPreceding, End: queryclause.CurrentRow},
+//	)
+func (builder *Builder) SelectWindow(alias string, fn clause.Expression, partitionBy []string, orderBy []clause.OrderByColumn, frame *queryclause.Frame) *Builder {
+	partitions := make([]clause.Column, 0, len(partitionBy))
+	for _, column := range partitionBy {
+		partitions = append(partitions, clause.Column{Name: column})
+	}
+	builder = builder.instance()
+	builder.selects.Add(selectColumn{
+		Column: clause.Column{Name: "?", Raw: true},
+		Vars: []any{queryclause.Window{
+			Function:    fn,
+			PartitionBy: partitions,
+			OrderBy:     orderBy,
+			Frame:       frame,
+			Alias:       alias,
+		}},
+	})
+	return builder
+}
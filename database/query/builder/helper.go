@@ -3,12 +3,14 @@ package builder
 import (
 	"database/sql"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/wardonne/gopi/database/exception"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
 // Callback query callback
@@ -109,3 +111,35 @@ func BuildTable(db *gorm.DB, table any, alias string, values ...any) string {
 	}
 	return ""
 }
+
+// TableName returns the table name of a table name, a [clause.Table] or a model
+//
+// models are resolved by their TableName method or gorm's default naming strategy
+func TableName(model any) string {
+	switch value := model.(type) {
+	case string:
+		return value
+	case clause.Table:
+		if value.Raw {
+			return ""
+		}
+		return value.Name
+	case schema.Tabler:
+		return value.TableName()
+	case fmt.Stringer:
+		return value.String()
+	case nil:
+		return ""
+	}
+	modelType := reflect.TypeOf(model)
+	for modelType.Kind() == reflect.Ptr || modelType.Kind() == reflect.Slice || modelType.Kind() == reflect.Array {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct {
+		return ""
+	}
+	if tabler, ok := reflect.New(modelType).Interface().(schema.Tabler); ok {
+		return tabler.TableName()
+	}
+	return schema.NamingStrategy{}.TableName(modelType.Name())
+}
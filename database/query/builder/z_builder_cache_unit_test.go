@@ -0,0 +1,150 @@
+package builder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/database/cache"
+)
+
+func TestBuilder_Remember(t *testing.T) {
+	store := cache.NewMemoryStore(100)
+	SetCacheStore(store)
+	defer SetCacheStore(nil)
+
+	type User struct {
+		ID   int
+		Name string
+	}
+
+	t.Run("Builder.Remember Find", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `name` = ?").WithArgs("user1").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1"))
+		for i := 0; i < 2; i++ {
+			var dest = make([]User, 0)
+			err := NewBuilder(mockDB).Table("users").Where("name", "user1").Remember(time.Minute).Find(&dest)
+			assert.Nil(t, err)
+			assert.Equal(t, []User{{ID: 1, Name: "user1"}}, dest)
+		}
+	})
+
+	t.Run("Builder.Remember different bindings", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `name` = ?").WithArgs("user2").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "user2"))
+		var dest = make([]User, 0)
+		err := NewBuilder(mockDB).Table("users").Where("name", "user2").Remember(time.Minute).Find(&dest)
+		assert.Nil(t, err)
+		assert.Equal(t, []User{{ID: 2, Name: "user2"}}, dest)
+	})
+
+	t.Run("Builder.Remember First", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `id` = ? ORDER BY `users`.`id` LIMIT ?").WithArgs(1, 1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1"))
+		for i := 0; i < 2; i++ {
+			var dest User
+			err := NewBuilder(mockDB).Where("id", 1).Remember(time.Minute).First(&dest)
+			assert.Nil(t, err)
+			assert.Equal(t, User{ID: 1, Name: "user1"}, dest)
+		}
+	})
+
+	t.Run("Builder.Remember Count", func(t *testing.T) {
+		mock.ExpectQuery("SELECT count(*) FROM `users`").WillReturnRows(countRows(5))
+		for i := 0; i < 2; i++ {
+			count, err := NewBuilder(mockDB).Table("users").Remember(time.Minute).Count()
+			assert.Nil(t, err)
+			assert.Equal(t, int64(5), count)
+		}
+	})
+
+	t.Run("Builder.Remember Pluck", func(t *testing.T) {
+		mock.ExpectQuery("SELECT `name` FROM `users`").WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("user1").AddRow("user2"))
+		for i := 0; i < 2; i++ {
+			var names []string
+			err := NewBuilder(mockDB).Table("users").Remember(time.Minute).Pluck("name", &names)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"user1", "user2"}, names)
+		}
+	})
+
+	t.Run("Builder.Remember custom key", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `orders`").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		for i := 0; i < 2; i++ {
+			var dest = make([]map[string]any, 0)
+			err := NewBuilder(mockDB).Table("orders").Remember(time.Minute, "orders:all").Find(&dest)
+			assert.Nil(t, err)
+			assert.Len(t, dest, 1)
+		}
+		_, ok := store.Get(CacheKeyPrefix + "orders:all")
+		assert.True(t, ok)
+	})
+
+	t.Run("Builder.Remember keeps value types", func(t *testing.T) {
+		createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		mock.ExpectQuery("SELECT * FROM `posts`").WillReturnRows(sqlmock.NewRows([]string{"id", "title", "created_at"}).AddRow(int64(9007199254740993), "post", createdAt))
+		results := make([][]map[string]any, 0, 2)
+		for i := 0; i < 2; i++ {
+			var dest = make([]map[string]any, 0)
+			err := NewBuilder(mockDB).Table("posts").Remember(time.Minute).Find(&dest)
+			assert.Nil(t, err)
+			results = append(results, dest)
+		}
+		assert.Equal(t, results[0], results[1])
+		assert.IsType(t, int64(0), results[1][0]["id"])
+		assert.Equal(t, int64(9007199254740993), results[1][0]["id"])
+		assert.IsType(t, time.Time{}, results[1][0]["created_at"])
+		assert.True(t, createdAt.Equal(results[1][0]["created_at"].(time.Time)))
+	})
+
+	t.Run("Builder.Remember flushed by writes", func(t *testing.T) {
+		mock.ExpectExec("UPDATE `users` SET `name`=? WHERE `id` = ?").WithArgs("user3", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := NewBuilder(mockDB).Table("users").Where("id", 1).Update(map[string]any{"name": "user3"})
+		assert.Nil(t, err)
+
+		mock.ExpectQuery("SELECT count(*) FROM `users`").WillReturnRows(countRows(6))
+		count, err := NewBuilder(mockDB).Table("users").Remember(time.Minute).Count()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(6), count)
+
+		_, ok := store.Get(CacheKeyPrefix + "orders:all")
+		assert.True(t, ok)
+		FlushCache("orders")
+		_, ok = store.Get(CacheKeyPrefix + "orders:all")
+		assert.False(t, ok)
+	})
+
+	t.Run("Builder.Remember flushed by json updates", func(t *testing.T) {
+		mock.ExpectQuery("SELECT count(*) FROM `profiles`").WillReturnRows(countRows(6))
+		count, err := NewBuilder(mockDB).Table("profiles").Remember(time.Minute).Count()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(6), count)
+
+		mock.ExpectExec("UPDATE `profiles` SET `meta`=JSON_SET(`meta`, ?, ?) WHERE `id` = ?").WithArgs(`$."city"`, "Paris", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		err = NewBuilder(mockDB).Table("profiles").Where("id", 1).UpdateJSON("meta", "city", "Paris")
+		assert.Nil(t, err)
+
+		mock.ExpectQuery("SELECT count(*) FROM `profiles`").WillReturnRows(countRows(7))
+		count, err = NewBuilder(mockDB).Table("profiles").Remember(time.Minute).Count()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(7), count)
+	})
+
+	t.Run("Builder.Remember without store", func(t *testing.T) {
+		SetCacheStore(nil)
+		defer SetCacheStore(store)
+		mock.ExpectQuery("SELECT count(*) FROM `users`").WillReturnRows(countRows(6))
+		mock.ExpectQuery("SELECT count(*) FROM `users`").WillReturnRows(countRows(7))
+		for _, expected := range []int64{6, 7} {
+			count, err := NewBuilder(mockDB).Table("users").Remember(time.Minute).Count()
+			assert.Nil(t, err)
+			assert.Equal(t, expected, count)
+		}
+	})
+}
+
+func countRows(count int) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id"})
+	for i := 1; i <= count; i++ {
+		rows.AddRow(i)
+	}
+	return rows
+}
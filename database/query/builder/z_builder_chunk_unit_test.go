@@ -0,0 +1,144 @@
+package builder
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_ChunkById(t *testing.T) {
+	type User struct {
+		ID   uint64 `gorm:"primaryKey"`
+		Name string
+	}
+
+	t.Run("Builder.ChunkById", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `status` = ? ORDER BY `id` LIMIT ?").WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1").AddRow(3, "user3"))
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `status` = ? AND `id` > ? ORDER BY `id` LIMIT ?").WithArgs(1, 3, 2).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(4, "user4"))
+		var dest []User
+		ids := make([]uint64, 0)
+		batches := make([]int, 0)
+		err := NewBuilder(mockDB).Table("users").Where("status", 1).OrderDesc("name").ChunkById(&dest, 2, func(batch int) error {
+			for _, user := range dest {
+				ids = append(ids, user.ID)
+			}
+			batches = append(batches, batch)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []uint64{1, 3, 4}, ids)
+		assert.Equal(t, []int{1, 2}, batches)
+	})
+
+	t.Run("Builder.ChunkById or conditions", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `status` = ? OR `role` = ? ORDER BY `id` LIMIT ?").WithArgs(1, "admin", 2).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1").AddRow(3, "user3"))
+		mock.ExpectQuery("SELECT * FROM `users` WHERE (`status` = ? OR `role` = ?) AND `id` > ? ORDER BY `id` LIMIT ?").WithArgs(1, "admin", 3, 2).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(4, "user4"))
+		var dest []User
+		ids := make([]uint64, 0)
+		err := NewBuilder(mockDB).Table("users").Where("status", 1).OrWhere("role", "admin").ChunkById(&dest, 2, func(batch int) error {
+			for _, user := range dest {
+				ids = append(ids, user.ID)
+			}
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []uint64{1, 3, 4}, ids)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Builder.ChunkById map", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` ORDER BY `users`.`uid` LIMIT ?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"uid"}).AddRow(10))
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `users`.`uid` > ? ORDER BY `users`.`uid` LIMIT ?").WithArgs(10, 1).WillReturnRows(sqlmock.NewRows([]string{"uid"}))
+		var dest []map[string]any
+		count := 0
+		err := NewBuilder(mockDB).Table("users").ChunkById(&dest, 1, func(batch int) error {
+			count += len(dest)
+			return nil
+		}, "users.uid")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Builder.ChunkById callback failure", func(t *testing.T) {
+		expectErr := errors.New("callback error")
+		mock.ExpectQuery("SELECT * FROM `users` ORDER BY `id` LIMIT ?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1"))
+		var dest []User
+		err := NewBuilder(mockDB).Table("users").ChunkById(&dest, 1, func(batch int) error {
+			return expectErr
+		})
+		assert.ErrorIs(t, err, expectErr)
+	})
+
+	t.Run("Builder.ChunkById query failure", func(t *testing.T) {
+		expectErr := errors.New("query error")
+		mock.ExpectQuery("SELECT * FROM `users` ORDER BY `id` LIMIT ?").WithArgs(1).WillReturnError(expectErr)
+		var dest []User
+		err := NewBuilder(mockDB).Table("users").ChunkById(&dest, 1, func(batch int) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, expectErr)
+	})
+
+	t.Run("ChunkById typed", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` ORDER BY `id` LIMIT ?").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1").AddRow(2, "user2"))
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `id` > ? ORDER BY `id` LIMIT ?").WithArgs(2, 2).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+		chunks := make([][]User, 0)
+		err := ChunkById(NewBuilder(mockDB).Table("users"), 2, func(users []User, batch int) error {
+			chunks = append(chunks, users)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, [][]User{{{ID: 1, Name: "user1"}, {ID: 2, Name: "user2"}}}, chunks)
+	})
+
+	t.Run("EachById", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` ORDER BY `id` LIMIT ?").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1").AddRow(2, "user2"))
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `id` > ? ORDER BY `id` LIMIT ?").WithArgs(2, 2).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "user3"))
+		names := make([]string, 0)
+		err := EachById(NewBuilder(mockDB).Table("users"), 2, func(user User) error {
+			names = append(names, user.Name)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"user1", "user2", "user3"}, names)
+	})
+}
+
+func TestChunkParallel(t *testing.T) {
+	type User struct {
+		ID uint64 `gorm:"primaryKey"`
+	}
+
+	t.Run("ChunkParallel", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` ORDER BY `id` LIMIT ?").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `id` > ? ORDER BY `id` LIMIT ?").WithArgs(2, 2).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(4))
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `id` > ? ORDER BY `id` LIMIT ?").WithArgs(4, 2).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+		var mu sync.Mutex
+		ids := make([]int, 0)
+		err := ChunkParallel(NewBuilder(mockDB).Table("users"), 2, 2, func(users []User, batch int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, user := range users {
+				ids = append(ids, int(user.ID))
+			}
+			return nil
+		})
+		assert.Nil(t, err)
+		sort.Ints(ids)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, ids)
+	})
+
+	t.Run("ChunkParallel failure", func(t *testing.T) {
+		expectErr := errors.New("callback error")
+		mock.ExpectQuery("SELECT * FROM `users` ORDER BY `id` LIMIT ?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `id` > ? ORDER BY `id` LIMIT ?").WithArgs(1, 1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		err := ChunkParallel(NewBuilder(mockDB).Table("users"), 1, 1, func(users []User, batch int) error {
+			return expectErr
+		})
+		assert.ErrorIs(t, err, expectErr)
+	})
+}
@@ -0,0 +1,52 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_When(t *testing.T) {
+	byName := func(builder *Builder) *Builder {
+		return builder.Where("name", "user1")
+	}
+	byStatus := func(builder *Builder) *Builder {
+		return builder.Where("status", 1)
+	}
+
+	t.Run("Builder.When true", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `name` = ?").WithArgs("user1").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").When(true, byName).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.When false", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users`").WithoutArgs().WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").When(false, byName).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.When fallback", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `status` = ?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").When(false, byName, byStatus).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.Unless", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `name` = ?").WithArgs("user1").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").Unless(false, byName).Unless(true, byStatus).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.Tap", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `name` = ? AND `status` = ?").WithArgs("user1", 1).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").Tap(byName).Tap(byStatus).Find(&dest)
+		assert.Nil(t, err)
+	})
+}
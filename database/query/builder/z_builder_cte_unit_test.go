@@ -0,0 +1,74 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_WithCTE(t *testing.T) {
+	t.Run("Builder.WithCTE select", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().
+			WithCTE("active_users", NewBuilder(mockDB).Table("users").Where("status", 1)).
+			Table("active_users").
+			ToSQL()
+		assert.Equal(t, "WITH `active_users` AS (SELECT * FROM `users` WHERE `status` = 1 ) SELECT * FROM `active_users`", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WithCTE join", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().
+			WithCTE("active_users", NewBuilder(mockDB).Table("users").Select("id").Where("status", 1)).
+			Table("orders").
+			InnerJoin("active_users", "active_users.id = orders.user_id").
+			ToSQL()
+		assert.Equal(t, "WITH `active_users` AS (SELECT `id` FROM `users` WHERE `status` = 1 ) SELECT * FROM `orders` INNER JOIN active_users ON active_users.id = orders.user_id", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WithCTE multiple with columns", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().
+			WithCTE("u", NewBuilder(mockDB).Table("users").Select("id", "name"), "user_id", "user_name").
+			WithCTE("d", NewBuilder(mockDB).Table("departments")).
+			Table("u").
+			ToSQL()
+		assert.Equal(t, "WITH `u` (`user_id`,`user_name`) AS (SELECT `id`,`name` FROM `users` ), `d` AS (SELECT * FROM `departments` ) SELECT * FROM `u`", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WithRecursive", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().
+			WithRecursive("tree",
+				NewBuilder(mockDB).Table("categories").Where("id", 1),
+				NewBuilder(mockDB).Table("categories").Select("categories.id", "categories.parent_id").InnerJoin("tree", "tree.id = categories.parent_id"),
+			).
+			Table("tree").
+			ToSQL()
+		assert.Equal(t, "WITH RECURSIVE `tree` AS (SELECT * FROM `categories` WHERE `id` = 1 UNION ALL SELECT `categories`.`id`,`categories`.`parent_id` FROM `categories` INNER JOIN tree ON tree.id = categories.parent_id ) SELECT * FROM `tree`", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WithCTE bindings", func(t *testing.T) {
+		mock.ExpectQuery("WITH `u` AS (SELECT * FROM `users` WHERE `status` = ? ) SELECT * FROM `u` WHERE `name` = ?").WithArgs(1, "user1").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).
+			WithCTE("u", NewBuilder(mockDB).Table("users").Where("status", 1)).
+			Table("u").
+			Where("name", "user1").
+			Find(&dest)
+		assert.Nil(t, err)
+		assert.Len(t, dest, 1)
+	})
+
+	t.Run("Builder.WithCTE count", func(t *testing.T) {
+		mock.ExpectQuery("WITH `u` AS (SELECT * FROM `users` WHERE `status` = ? ) SELECT count(*) FROM `u`").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(3))
+		_, err := NewBuilder(mockDB).
+			WithCTE("u", NewBuilder(mockDB).Table("users").Where("status", 1)).
+			Table("u").
+			Count()
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.WithCTE invalid query", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewBuilder(mockDB).WithCTE("u", "users")
+		})
+	})
+}
@@ -22,3 +22,23 @@ func TestBuilder_Delete(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestBuilder_JoinedDelete(t *testing.T) {
+	t.Run("Builder.Delete joined mysql", func(t *testing.T) {
+		mock.ExpectExec("DELETE `users` FROM `users` INNER JOIN bans ON bans.user_id = users.id WHERE `bans`.`active` = ?").WithArgs(true).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := NewBuilder(mockDB).Table("users").InnerJoin("bans", "bans.user_id = users.id").Where("bans.active", true).Delete()
+		assert.Nil(t, err)
+	})
+
+	for name, expected := range map[string]string{
+		"postgres": `DELETE FROM "users" USING bans WHERE bans.user_id = users.id AND "bans"."active" = $1`,
+		"sqlite":   "DELETE FROM `users` WHERE rowid IN (SELECT `users`.rowid FROM `users` INNER JOIN bans ON bans.user_id = users.id WHERE `bans`.`active` = ?)",
+	} {
+		t.Run("Builder.Delete joined "+name, func(t *testing.T) {
+			db, recorder := newDialectRecorder(name)
+			err := NewBuilder(db).Table("users").InnerJoin("bans", "bans.user_id = users.id").Where("bans.active", true).Delete()
+			assert.Nil(t, err)
+			assert.Equal(t, expected, recorder.Last().SQL)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Filter(t *testing.T) {
+	rules := FilterRules{
+		"name":       {FilterEq, FilterLike},
+		"status":     {FilterIn},
+		"created_at": {FilterBetween, FilterSort},
+		"id":         {FilterSort},
+	}
+
+	t.Run("Builder.Filter", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `created_at` BETWEEN ? AND ? AND `name` LIKE ? AND `status` IN (?,?) ORDER BY `created_at` DESC,`id`").
+			WithArgs("2023-01-01", "2023-12-31", "%john%", "1", "2").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "john"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").Filter(map[string]any{
+			"name":       map[string]any{"like": "john"},
+			"status":     map[string]any{"in": "1,2"},
+			"created_at": map[string]any{"between": []string{"2023-01-01", "2023-12-31"}},
+			"sort":       "-created_at,id",
+		}, rules).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.Filter eq", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `name` = ?").WithArgs("john").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "john"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").Filter(map[string]any{"name": "john"}, rules).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.Filter not whitelisted", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users`").WithoutArgs().WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "john"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").Filter(map[string]any{
+			"password": "secret",
+			"status":   "1",
+			"name":     map[string]any{"between": "a,b"},
+			"sort":     []string{"-password", "name"},
+		}, rules).Find(&dest)
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,94 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/database/exception"
+	queryclause "github.com/wardonne/gopi/database/query/clause"
+)
+
+func TestBuilder_WhereFullText(t *testing.T) {
+	t.Run("Builder.WhereFullText mysql natural", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `posts` WHERE MATCH (`title`,`body`) AGAINST (? IN NATURAL LANGUAGE MODE)").WithArgs("database").WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "post"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("posts").WhereFullText([]string{"title", "body"}, "database", queryclause.FullTextNatural).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.WhereFullText mysql boolean", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `posts` WHERE `status` = ? OR MATCH (`title`) AGAINST (? IN BOOLEAN MODE)").WithArgs(1, "+mysql -oracle").WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "post"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("posts").Where("status", 1).OrWhereFullText([]string{"title"}, "+mysql -oracle", queryclause.FullTextBoolean).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.WhereFullText mysql relevance", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `posts` WHERE MATCH (`title`) AGAINST (? WITH QUERY EXPANSION) ORDER BY MATCH (`title`) AGAINST (? WITH QUERY EXPANSION) DESC,`id` DESC").WithArgs("database", "database").WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "post"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("posts").OrderDesc("id").WhereFullText([]string{"title"}, "database", queryclause.FullTextQueryExpansion).OrderByRelevance().Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.WhereFullText postgres", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("postgres")).Table("posts").WhereFullText([]string{"title", "body"}, "database", queryclause.FullTextNatural).OrderByRelevance().ToSQL()
+		assert.Equal(t, `SELECT * FROM "posts" WHERE to_tsvector(coalesce("title", '') || ' ' || coalesce("body", '')) @@ plainto_tsquery('database') ORDER BY ts_rank(to_tsvector(coalesce("title", '') || ' ' || coalesce("body", '')), plainto_tsquery('database')) DESC`, normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WhereFullText postgres boolean", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("postgres")).Table("posts").WhereFullText([]string{"title"}, "mysql & !oracle", queryclause.FullTextBoolean).ToSQL()
+		assert.Equal(t, `SELECT * FROM "posts" WHERE to_tsvector("title") @@ to_tsquery('mysql & !oracle')`, normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WhereFullText sqlite", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("sqlite")).Table("posts").WhereFullText([]string{"title", "body"}, "database", queryclause.FullTextNatural).OrderByRelevance().ToSQL()
+		assert.Equal(t, "SELECT * FROM `posts` WHERE `posts` MATCH '{title body}: (database)' ORDER BY bm25(`posts`)", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WhereFullText sqlite single column", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("sqlite")).Table("posts").WhereFullText([]string{"title"}, "database", queryclause.FullTextNatural).ToSQL()
+		assert.Equal(t, "SELECT * FROM `posts` WHERE `title` MATCH 'database'", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.OrderByRelevance keeps raw order bindings", func(t *testing.T) {
+		db, recorder := newDialectRecorder("postgres")
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(db).Table("posts").
+			OrderByRaw("array_position(?, status)", "{published,draft}").
+			WhereFullText([]string{"title"}, "database", queryclause.FullTextNatural).
+			OrderByRelevance().
+			OrderDesc("id").
+			Find(&dest)
+		assert.Nil(t, err)
+		assert.Equal(t, `SELECT * FROM "posts" WHERE to_tsvector("title") @@ plainto_tsquery($1) ORDER BY ts_rank(to_tsvector("title"), plainto_tsquery($2)) DESC,array_position($3, status),"id" DESC`, normalizeSQL(recorder.Last().SQL))
+		assert.Equal(t, []any{"database", "database", "{published,draft}"}, recorder.Last().Vars)
+	})
+
+	t.Run("Builder.WhereFullText empty column", func(t *testing.T) {
+		assert.PanicsWithError(t, exception.NewEmptyFullTextColumnErr().Error(), func() {
+			NewBuilder(mockDB).Table("posts").WhereFullText([]string{"title", ""}, "database", queryclause.FullTextNatural)
+		})
+		assert.PanicsWithError(t, exception.NewEmptyFullTextColumnErr().Error(), func() {
+			NewBuilder(mockDB).Table("posts").WhereFullText(nil, "database", queryclause.FullTextNatural)
+		})
+	})
+
+	t.Run("Builder.WhereFullText sqlite without table", func(t *testing.T) {
+		assert.PanicsWithError(t, exception.NewFullTextTableNotFoundErr().Error(), func() {
+			NewBuilder(newDialectDB("sqlite")).WhereFullText([]string{"title", "body"}, "database", queryclause.FullTextNatural)
+		})
+		assert.PanicsWithError(t, exception.NewFullTextTableNotFoundErr().Error(), func() {
+			NewBuilder(newDialectDB("sqlite")).WhereFullText([]string{"title"}, "database", queryclause.FullTextNatural).OrderByRelevance()
+		})
+		assert.NotPanics(t, func() {
+			NewBuilder(newDialectDB("sqlite")).WhereFullText([]string{"title"}, "database", queryclause.FullTextNatural)
+		})
+	})
+
+	t.Run("Builder.OrderByRelevance without full-text", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewBuilder(mockDB).Table("posts").OrderByRelevance()
+		})
+	})
+}
@@ -61,3 +61,13 @@ func TestBuilder_Group(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestBuilder_GroupByRaw(t *testing.T) {
+	result := sqlmock.NewRows([]string{"month", "total"})
+	result.AddRow("2024-01", 1)
+
+	mock.ExpectQuery("SELECT * FROM `orders` GROUP BY DATE_FORMAT(created_at, ?),`status` HAVING SUM(amount) > ?").WithArgs("%Y-%m", 100).WillReturnRows(result)
+	var dest = make([]map[string]any, 0)
+	err := NewBuilder(mockDB).Table("orders").GroupByRaw("DATE_FORMAT(created_at, ?)", "%Y-%m").Group("status").HavingRaw("SUM(amount) > ?", 100).Find(&dest)
+	assert.Nil(t, err)
+}
@@ -1576,3 +1576,24 @@ func TestBuilder_OrHavingNotCallback(t *testing.T) {
 		Find(&dest)
 	assert.Nil(t, err)
 }
+
+func TestBuilder_HavingRaw(t *testing.T) {
+	result := sqlmock.NewRows([]string{"id", "name"})
+	for _, user := range mockUsers {
+		result.AddRow(user["id"], user["name"])
+	}
+
+	t.Run("Builder.HavingRaw", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` GROUP BY `department_id` HAVING COUNT(*) > ? AND `department_id` = ?").WithArgs(3, 1).WillReturnRows(result)
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").Group("department_id").HavingRaw("COUNT(*) > ?", 3).Having("department_id", 1).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.OrHavingRaw", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` GROUP BY `department_id` HAVING `department_id` = ? OR COUNT(*) > ?").WithArgs(1, 3).WillReturnRows(result)
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").Group("department_id").Having("department_id", 1).OrHavingRaw("COUNT(*) > ?", 3).Find(&dest)
+		assert.Nil(t, err)
+	})
+}
@@ -1,11 +1,13 @@
 package builder
 
 import (
+	"database/sql"
 	"errors"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestBuilder_FirstOrCreate(t *testing.T) {
@@ -148,3 +150,119 @@ func TestBuilder_Upsert(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestBuilder_InsertOrIgnore(t *testing.T) {
+	t.Run("Builder.InsertOrIgnore mysql", func(t *testing.T) {
+		mock.ExpectExec("INSERT IGNORE INTO `users` (`email`,`name`) VALUES (?,?)").WithArgs("john@example.com", "john").WillReturnResult(sqlmock.NewResult(0, 0))
+		affected, err := NewBuilder(mockDB).Table("users").InsertOrIgnore(map[string]any{
+			"email": "john@example.com",
+			"name":  "john",
+		})
+		assert.Nil(t, err)
+		assert.EqualValues(t, 0, affected)
+	})
+
+	for name, expected := range map[string]string{
+		"postgres": `INSERT INTO "users" ("email","name") VALUES ($1,$2) ON CONFLICT DO NOTHING`,
+		"sqlite":   "INSERT INTO `users` (`email`,`name`) VALUES (?,?) ON CONFLICT DO NOTHING",
+	} {
+		t.Run("Builder.InsertOrIgnore "+name, func(t *testing.T) {
+			db, recorder := newDialectRecorder(name)
+			_, err := NewBuilder(db).Table("users").InsertOrIgnore(map[string]any{
+				"email": "john@example.com",
+				"name":  "john",
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, expected, recorder.Last().SQL)
+			assert.Equal(t, []any{"john@example.com", "john"}, recorder.Last().Vars)
+		})
+	}
+}
+
+func TestBuilder_InsertUsing(t *testing.T) {
+	t.Run("Builder.InsertUsing mysql", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO `archived_users` (`id`,`name`) SELECT `id`,`name` FROM `users` WHERE `status` = ?").WithArgs(0).WillReturnResult(sqlmock.NewResult(0, 2))
+		affected, err := NewBuilder(mockDB).Table("archived_users").InsertUsing([]string{"id", "name"}, NewBuilder(mockDB).Table("users").Select("id", "name").Where("status", 0))
+		assert.Nil(t, err)
+		assert.EqualValues(t, 2, affected)
+	})
+
+	t.Run("Builder.InsertUsing postgres", func(t *testing.T) {
+		db, recorder := newDialectRecorder("postgres")
+		_, err := NewBuilder(db).Table("archived_users").Where("id", 1).InsertUsing([]string{"id", "name"}, NewBuilder(db).Table("users").Select("id", "name").Where("status", 0).WhereGt("id", 10))
+		assert.Nil(t, err)
+		assert.Equal(t, `INSERT INTO "archived_users" ("id","name") SELECT "id","name" FROM "users" WHERE "status" = $1 AND "id" > $2`, normalizeSQL(recorder.Last().SQL))
+		assert.Equal(t, []any{0, 10}, recorder.Last().Vars)
+	})
+
+	t.Run("Builder.InsertUsing invalid query", func(t *testing.T) {
+		assert.Panics(t, func() {
+			_, _ = NewBuilder(mockDB).Table("archived_users").InsertUsing([]string{"id"}, "users")
+		})
+	})
+}
+
+func TestBuilder_UpdateOrInsert(t *testing.T) {
+	t.Run("Builder.UpdateOrInsert update", func(t *testing.T) {
+		mock.ExpectQuery("SELECT EXISTS (SELECT * FROM `users` WHERE `email` = ? ) AS `result`").WithArgs("john@example.com").WillReturnRows(sqlmock.NewRows([]string{"result"}).AddRow(true))
+		mock.ExpectExec("UPDATE `users` SET `name`=? WHERE `email` = ?").WithArgs("John", "john@example.com").WillReturnResult(sqlmock.NewResult(0, 1))
+		err := NewBuilder(mockDB).Table("users").UpdateOrInsert(map[string]any{"email": "john@example.com"}, map[string]any{"name": "John"})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.UpdateOrInsert insert", func(t *testing.T) {
+		mock.ExpectQuery("SELECT EXISTS (SELECT * FROM `users` WHERE `email` = ? ) AS `result`").WithArgs("john@example.com").WillReturnRows(sqlmock.NewRows([]string{"result"}).AddRow(false))
+		mock.ExpectExec("INSERT INTO `users` (`email`,`name`) VALUES (?,?)").WithArgs("john@example.com", "John").WillReturnResult(sqlmock.NewResult(1, 1))
+		err := NewBuilder(mockDB).Table("users").UpdateOrInsert(map[string]any{"email": "john@example.com"}, map[string]any{"name": "John"})
+		assert.Nil(t, err)
+	})
+	t.Run("Builder.UpdateOrInsert keeps builder conditions", func(t *testing.T) {
+		mock.ExpectQuery("SELECT EXISTS (SELECT * FROM `users` WHERE `tenant_id` = ? AND `email` = ? ) AS `result`").WithArgs(1, "john@example.com").WillReturnRows(sqlmock.NewRows([]string{"result"}).AddRow(true))
+		mock.ExpectExec("UPDATE `users` SET `name`=? WHERE `tenant_id` = ? AND `email` = ?").WithArgs("John", 1, "john@example.com").WillReturnResult(sqlmock.NewResult(0, 1))
+		err := NewBuilder(mockDB).Table("users").Where("tenant_id", 1).UpdateOrInsert(map[string]any{"email": "john@example.com"}, map[string]any{"name": "John"})
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Builder.UpdateOrInsert postgres", func(t *testing.T) {
+		db, recorder := newDialectRecorder("postgres")
+		// rows can't be scanned in dry run, the existence check is the last executed statement
+		err := NewBuilder(db).Table("users").UpdateOrInsert(map[string]any{"email": "john@example.com"}, map[string]any{"name": "John"})
+		assert.ErrorIs(t, err, gorm.ErrDryRunModeUnsupported)
+		assert.Equal(t, `SELECT EXISTS (SELECT * FROM "users" WHERE "email" = $1 ) AS "result"`, normalizeSQL(recorder.Last().SQL))
+		assert.Equal(t, []any{"john@example.com"}, recorder.Last().Vars)
+	})
+
+	t.Run("Builder.UpdateOrInsert in transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT EXISTS (SELECT * FROM `users` WHERE `email` = ? ) AS `result`").WithArgs("john@example.com").WillReturnRows(sqlmock.NewRows([]string{"result"}).AddRow(false))
+		mock.ExpectExec("INSERT INTO `users` (`email`,`name`) VALUES (?,?)").WithArgs("john@example.com", "John").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("SELECT EXISTS (SELECT * FROM `users` WHERE `email` = ? ) AS `result`").WithArgs("john@example.com").WillReturnRows(sqlmock.NewRows([]string{"result"}).AddRow(true))
+		mock.ExpectExec("UPDATE `users` SET `name`=? WHERE `email` = ?").WithArgs("Johnny", "john@example.com").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		var outside []string
+		record := func(tx *gorm.DB) {
+			if _, ok := tx.Statement.ConnPool.(*sql.Tx); !ok {
+				outside = append(outside, tx.Statement.SQL.String())
+			}
+		}
+		callback := mockDB.Callback()
+		assert.Nil(t, callback.Row().After("*").Register("test:transaction", record))
+		assert.Nil(t, callback.Create().After("*").Register("test:transaction", record))
+		assert.Nil(t, callback.Update().After("*").Register("test:transaction", record))
+		defer func() {
+			_ = callback.Row().Remove("test:transaction")
+			_ = callback.Create().Remove("test:transaction")
+			_ = callback.Update().Remove("test:transaction")
+		}()
+		err := NewBuilder(mockDB).Transaction(func(builder *Builder) error {
+			if err := builder.Table("users").UpdateOrInsert(map[string]any{"email": "john@example.com"}, map[string]any{"name": "John"}); err != nil {
+				return err
+			}
+			return builder.Table("users").UpdateOrInsert(map[string]any{"email": "john@example.com"}, map[string]any{"name": "Johnny"})
+		})
+		assert.Nil(t, err)
+		assert.Empty(t, outside)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
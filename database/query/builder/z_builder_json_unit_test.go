@@ -5,6 +5,8 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	queryclause "github.com/wardonne/gopi/database/query/clause"
+	"gorm.io/gorm"
 )
 
 func TestBuilder_WhereJSONContains(t *testing.T) {
@@ -298,3 +300,111 @@ func TestBuilder_OrHavingJSONNotHasKey(t *testing.T) {
 	err := NewBuilder(mockDB).Table("users").Having("status", 1).OrHavingJSONNotHasKey("meta", "department", "status").Find(&dest)
 	assert.Nil(t, err)
 }
+
+func TestBuilder_WhereJSON(t *testing.T) {
+	t.Run("Builder.WhereJSON mysql", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE JSON_UNQUOTE(JSON_EXTRACT(`meta`, ?)) = ?").WithArgs(`$."address"."city"`, "Paris").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "wardonne"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").WhereJSON("meta->address->city", "=", "Paris").Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.OrWhereJSON mysql", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `status` = ? OR JSON_EXTRACT(`meta`, ?) = true").WithArgs(1, `$."active"`).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "wardonne"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").Where("status", 1).OrWhereJSON("meta->active", "=", true).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.WhereJSON postgres", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("postgres")).Table("users").WhereJSON("meta->address->city", "=", "Paris").WhereJSON("meta->age", ">", 18).ToSQL()
+		assert.Equal(t, `SELECT * FROM "users" WHERE ("meta"::jsonb #>> '{address,city}') = 'Paris' AND ("meta"::jsonb #>> '{age}')::numeric > 18`, normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WhereJSON sqlite", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("sqlite")).Table("users").WhereJSON("meta->tags->0", "like", "go%").ToSQL()
+		assert.Equal(t, "SELECT * FROM `users` WHERE json_extract(`meta`, '$.\"tags\"[0]') LIKE 'go%'", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WhereJSON invalid operator", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewBuilder(mockDB).Table("users").WhereJSON("meta->age", "in", 18)
+		})
+	})
+}
+
+func TestBuilder_WhereJSONLength(t *testing.T) {
+	t.Run("Builder.WhereJSONLength mysql", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE JSON_LENGTH(`tags`) > ? OR JSON_LENGTH(`meta`, ?) = ?").WithArgs(2, `$."roles"`, 0).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "wardonne"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").WhereJSONLength("tags", ">", 2).OrWhereJSONLength("meta->roles", "=", 0).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.WhereJSONLength postgres", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("postgres")).Table("users").WhereJSONLength("meta->roles", ">", 2).ToSQL()
+		assert.Equal(t, `SELECT * FROM "users" WHERE jsonb_array_length("meta"::jsonb #> '{roles}') > 2`, normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WhereJSONLength sqlite", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("sqlite")).Table("users").WhereJSONLength("tags", ">=", 1).ToSQL()
+		assert.Equal(t, "SELECT * FROM `users` WHERE json_array_length(`tags`) >= 1", normalizeSQL(sql))
+	})
+}
+
+func TestBuilder_JSONDialects(t *testing.T) {
+	t.Run("Builder.WhereJSONContains postgres", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("postgres")).Table("users").WhereJSONContains("tags", 1).ToSQL()
+		assert.Equal(t, `SELECT * FROM "users" WHERE "tags"::jsonb @> '[1]'::jsonb`, normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WhereJSONContains sqlite", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("sqlite")).Table("users").WhereJSONContains("tags", "go").ToSQL()
+		assert.Equal(t, "SELECT * FROM `users` WHERE EXISTS (SELECT 1 FROM json_each(`tags`) WHERE json_each.value = 'go')", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WhereJSONOverlaps postgres", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("postgres")).Table("users").WhereJSONOverlaps("tags", `["a","b"]`).ToSQL()
+		assert.Equal(t, `SELECT * FROM "users" WHERE "tags"::jsonb ?| ARRAY(SELECT jsonb_array_elements_text('["a","b"]'::jsonb))`, normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WhereJSONOverlaps sqlite", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("sqlite")).Table("users").WhereJSONOverlaps("tags", "[1,2]").ToSQL()
+		assert.Equal(t, "SELECT * FROM `users` WHERE EXISTS (SELECT 1 FROM json_each(`tags`) AS a, json_each('[1,2]') AS b WHERE a.value = b.value)", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WhereJSONContainsPath postgres", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("postgres")).Table("users").WhereJSONContainsPath("meta", true, "$.a", "$.b").ToSQL()
+		assert.Equal(t, `SELECT * FROM "users" WHERE (jsonb_path_exists("meta"::jsonb, '$.a') AND jsonb_path_exists("meta"::jsonb, '$.b'))`, normalizeSQL(sql))
+	})
+
+	t.Run("Builder.WhereJSONContainsPath sqlite", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("sqlite")).Table("users").WhereJSONContainsPath("meta", false, "$.a").ToSQL()
+		assert.Equal(t, "SELECT * FROM `users` WHERE json_type(`meta`, '$.a') IS NOT NULL", normalizeSQL(sql))
+	})
+}
+
+func TestBuilder_UpdateJSON(t *testing.T) {
+	t.Run("Builder.UpdateJSON mysql", func(t *testing.T) {
+		mock.ExpectExec("UPDATE `users` SET `meta`=JSON_SET(`meta`, ?, ?) WHERE `id` = ?").WithArgs(`$."address"."city"`, "Paris", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := NewBuilder(mockDB).Table("users").Where("id", 1).UpdateJSON("meta", "address->city", "Paris")
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.UpdateJSON mysql composite", func(t *testing.T) {
+		mock.ExpectExec("UPDATE `users` SET `meta`=JSON_SET(`meta`, ?, CAST(? AS JSON)) WHERE `id` = ?").WithArgs(`$."roles"`, `["admin"]`, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := NewBuilder(mockDB).Table("users").Where("id", 1).UpdateJSON("meta", "roles", []string{"admin"})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.UpdateJSON postgres", func(t *testing.T) {
+		db := newDialectDB("postgres")
+		stmt := NewBuilder(db).Table("users").Where("id", 1).DB().Session(&gorm.Session{}).Update("meta", queryclause.JSONSet{
+			Column: `"meta"`,
+			Keys:   []string{"address", "city"},
+			Value:  "Paris",
+		}).Statement
+		assert.Equal(t, `UPDATE "users" SET "meta"=jsonb_set("meta"::jsonb, $1, $2::jsonb) WHERE "id" = $3`, stmt.SQL.String())
+		assert.Equal(t, []any{"{address,city}", `"Paris"`, 1}, stmt.Vars)
+	})
+}
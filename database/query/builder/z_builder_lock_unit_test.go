@@ -0,0 +1,71 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/database/exception"
+)
+
+func TestBuilder_Lock(t *testing.T) {
+	t.Run("Builder.LockForUpdate", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT * FROM `products` WHERE `id` = ? FOR UPDATE").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "stock"}).AddRow(1, 10))
+		mock.ExpectExec("UPDATE `products` SET `stock`=? WHERE `id` = ?").WithArgs(9, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		err := NewBuilder(mockDB).Transaction(func(builder *Builder) error {
+			var dest = make([]map[string]any, 0)
+			if err := builder.Table("products").Where("id", 1).LockForUpdate().Find(&dest); err != nil {
+				return err
+			}
+			return builder.Table("products").Where("id", 1).Update(map[string]any{"stock": 9})
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.SharedLock", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT * FROM `products` WHERE `id` = ? FOR SHARE NOWAIT").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "stock"}).AddRow(1, 10))
+		mock.ExpectCommit()
+		builder := NewBuilder(mockDB)
+		builder.Begin()
+		var dest = make([]map[string]any, 0)
+		err := builder.Table("products").Where("id", 1).SharedLock().NoWait().Find(&dest)
+		assert.Nil(t, err)
+		assert.Nil(t, builder.Commit())
+	})
+
+	t.Run("Builder.SkipLocked", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT * FROM `jobs` WHERE `status` = ? LIMIT ? FOR UPDATE SKIP LOCKED").WithArgs(0, 1).WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, 0))
+		mock.ExpectCommit()
+		builder := NewBuilder(mockDB)
+		builder.Begin()
+		var dest = make([]map[string]any, 0)
+		err := builder.Table("jobs").Where("status", 0).Limit(1).SkipLocked().Find(&dest)
+		assert.Nil(t, err)
+		assert.Nil(t, builder.Commit())
+	})
+
+	t.Run("Builder.LockForUpdate without transaction", func(t *testing.T) {
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("products").Where("id", 1).LockForUpdate().Find(&dest)
+		var lockErr *exception.LockWithoutTransactionErr
+		assert.ErrorAs(t, err, &lockErr)
+	})
+
+	t.Run("Builder.LockForUpdate postgres", func(t *testing.T) {
+		builder := NewBuilder(newDialectDB("postgres"))
+		builder.onTransaction = true
+		sql := builder.Table("products").Where("id", 1).LockForUpdate().SkipLocked().ToSQL()
+		assert.Equal(t, `SELECT * FROM "products" WHERE "id" = 1 FOR UPDATE SKIP LOCKED`, normalizeSQL(sql))
+	})
+
+	t.Run("Builder.LockForUpdate sqlite", func(t *testing.T) {
+		builder := NewBuilder(newDialectDB("sqlite"))
+		builder.onTransaction = true
+		sql := builder.Table("products").Where("id", 1).LockForUpdate().ToSQL()
+		assert.Equal(t, "SELECT * FROM `products` WHERE `id` = 1", normalizeSQL(sql))
+	})
+}
@@ -101,3 +101,31 @@ func TestBuilder_OrderDesc(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestBuilder_OrderByRaw(t *testing.T) {
+	result := sqlmock.NewRows([]string{"id", "name"})
+	for _, user := range mockUsers {
+		result.AddRow(user["id"], user["name"])
+	}
+
+	t.Run("Builder.OrderByRaw with bindings", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` ORDER BY FIELD(status, ?, ?),`id` DESC").WithArgs("paid", "pending").WillReturnRows(result)
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").OrderByRaw("FIELD(status, ?, ?)", "paid", "pending").OrderDesc("id").Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.OrderByRaw same sql", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` ORDER BY FIELD(status, ?),FIELD(status, ?)").WithArgs("paid", "pending").WillReturnRows(result)
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").OrderByRaw("FIELD(status, ?)", "paid").OrderByRaw("FIELD(status, ?)", "pending").Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.OrderByRaw without bindings", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` ORDER BY created_at DESC").WithoutArgs().WillReturnRows(result)
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").OrderByRaw("created_at DESC").Find(&dest)
+		assert.Nil(t, err)
+	})
+}
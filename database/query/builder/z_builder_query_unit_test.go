@@ -1,8 +1,10 @@
 package builder
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
@@ -190,3 +192,24 @@ func TestBuilder_Exists(t *testing.T) {
 	})
 
 }
+
+func TestBuilder_WithContext(t *testing.T) {
+	t.Run("Builder.WithContext canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).WithContext(ctx).Table("users").Find(&dest)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Builder.WithContext deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+		query := NewBuilder(mockDB).WithContext(ctx).Table("users")
+		_, ok := query.Context().Deadline()
+		assert.True(t, ok)
+		mock.ExpectQuery("SELECT * FROM `users`").WithoutArgs().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		var dest = make([]map[string]any, 0)
+		assert.Nil(t, query.Find(&dest))
+	})
+}
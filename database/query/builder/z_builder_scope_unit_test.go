@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/database/exception"
 	"gorm.io/gorm"
 )
 
@@ -16,3 +17,85 @@ func TestBuilder_Scopes(t *testing.T) {
 	}).Find(&dest)
 	assert.Nil(t, err)
 }
+
+func TestBuilder_GlobalScope(t *testing.T) {
+	type Post struct {
+		ID        uint64
+		Title     string
+		Published bool
+	}
+	RegisterGlobalScope(new(Post), "published", func(builder *Builder) *Builder {
+		return builder.Where("published", true)
+	})
+	RegisterGlobalScope("posts", "visible", func(builder *Builder) *Builder {
+		return builder.WhereNull("hidden_at")
+	})
+	defer RemoveGlobalScope("posts", "published")
+	defer RemoveGlobalScope("posts", "visible")
+
+	t.Run("Builder.GlobalScope table", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `posts` WHERE `id` = ? AND `published` = ? AND `hidden_at` IS NULL").WithArgs(1, true).WillReturnRows(mock.NewRows([]string{"id", "title"}).AddRow(1, "post"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("posts").Where("id", 1).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.GlobalScope model", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `posts` WHERE `published` = ? AND `hidden_at` IS NULL").WithArgs(true).WillReturnRows(mock.NewRows([]string{"id", "title"}).AddRow(1, "post"))
+		var dest = make([]Post, 0)
+		err := NewBuilder(mockDB).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.GlobalScope with or conditions", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `posts` WHERE (`id` = ? OR `id` = ?) AND `published` = ? AND `hidden_at` IS NULL").WithArgs(1, 2, true).WillReturnRows(mock.NewRows([]string{"id", "title"}).AddRow(1, "post"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("posts").Where("id", 1).OrWhere("id", 2).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.WithoutGlobalScope", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `posts` WHERE `hidden_at` IS NULL").WithoutArgs().WillReturnRows(mock.NewRows([]string{"id", "title"}).AddRow(1, "post"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("posts").WithoutGlobalScope("published").Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.WithoutGlobalScopes", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `posts`").WithoutArgs().WillReturnRows(mock.NewRows([]string{"id", "title"}).AddRow(1, "post"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("posts").WithoutGlobalScopes().Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.GlobalScope other table", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users`").WithoutArgs().WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(1, "wardonne"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").Find(&dest)
+		assert.Nil(t, err)
+	})
+}
+
+func TestBuilder_Scope(t *testing.T) {
+	RegisterLocalScope("users", "active", func(builder *Builder, args ...any) *Builder {
+		return builder.Where("status", 1)
+	})
+	RegisterLocalScope("users", "olderThan", func(builder *Builder, args ...any) *Builder {
+		return builder.WhereGt("age", args[0])
+	})
+	defer RemoveLocalScope("users", "active")
+	defer RemoveLocalScope("users", "olderThan")
+
+	t.Run("Builder.Scope", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `status` = ? AND `age` > ?").WithArgs(1, 18).WillReturnRows(mock.NewRows([]string{"id", "name"}).AddRow(1, "wardonne"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").Scope("active").Scope("olderThan", 18).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.Scope not found", func(t *testing.T) {
+		assert.PanicsWithError(t, exception.NewScopeNotFoundErr("users", "inactive").Error(), func() {
+			NewBuilder(mockDB).Table("users").Scope("inactive")
+		})
+	})
+}
@@ -179,3 +179,88 @@ func TestBuilder_Distinct(t *testing.T) {
 	err := NewBuilder(mockDB).Table("users").Distinct("id").Find(&dest)
 	assert.Nil(t, err)
 }
+
+func TestBuilder_AddSelect(t *testing.T) {
+	result := sqlmock.NewRows([]string{"id", "name", "total"})
+	for _, user := range mockUsers {
+		result.AddRow(user["id"], user["name"], 1)
+	}
+
+	mock.ExpectQuery("SELECT `id`,`name`,price * ? AS total FROM `orders` ORDER BY FIELD(status, ?)").WithArgs(2, "paid").WillReturnRows(result)
+	var dest = make([]map[string]any, 0)
+	builder := NewBuilder(mockDB).Table("orders").Select("id").AddSelect("name").SelectRaw("price * ? AS total", 2).OrderByRaw("FIELD(status, ?)", "paid")
+	assert.Equal(t, "SELECT `id`,`name`,price * 2 AS total FROM `orders` ORDER BY FIELD(status, 'paid')", normalizeSQL(builder.ToSQL()))
+	err := builder.Find(&dest)
+	assert.Nil(t, err)
+}
+
+func TestBuilder_SelectRaw(t *testing.T) {
+	result := sqlmock.NewRows([]string{"id", "price"})
+	for _, user := range mockUsers {
+		result.AddRow(user["id"], 1)
+	}
+
+	mock.ExpectQuery("SELECT `id`,price * ? AS price FROM `orders`").WithArgs(2).WillReturnRows(result)
+	var dest = make([]map[string]any, 0)
+	err := NewBuilder(mockDB).Table("orders").Select("id").SelectRaw("price * ? AS price", 2).Find(&dest)
+	assert.Nil(t, err)
+}
+
+func TestBuilder_SelectSub(t *testing.T) {
+	result := sqlmock.NewRows([]string{"id", "orders"})
+	for _, user := range mockUsers {
+		result.AddRow(user["id"], 1)
+	}
+
+	t.Run("Builder.SelectSub(*Builder)", func(t *testing.T) {
+		mock.ExpectQuery("SELECT `id`,(SELECT COUNT(*) FROM `orders` WHERE `status` = ? AND orders.user_id = users.id ) AS `orders` FROM `users`").WithArgs("paid").WillReturnRows(result)
+		var dest = make([]map[string]any, 0)
+		subQuery := NewBuilder(mockDB).Table("orders").Select(clause.Column{Name: "COUNT(*)", Raw: true}).Where("status", "paid").WhereRaw("orders.user_id = users.id")
+		err := NewBuilder(mockDB).Table("users").Select("id").SelectSub(subQuery, "orders").Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.SelectSub(*gorm.DB)", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (SELECT MAX(amount) FROM `orders` WHERE status = ?) AS `max_amount` FROM `users`").WithArgs("paid").WillReturnRows(result)
+		var dest = make([]map[string]any, 0)
+		subQuery := mockDB.Table("orders").Select("MAX(amount)").Where("status = ?", "paid")
+		err := NewBuilder(mockDB).Table("users").SelectSub(subQuery, "max_amount").Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.SelectSub without alias", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewBuilder(mockDB).Table("users").SelectSub(mockDB.Table("orders"), "")
+		})
+	})
+}
+
+func TestBuilder_RawBindings(t *testing.T) {
+	t.Run("postgres placeholders are numbered in clause order", func(t *testing.T) {
+		db, recorder := newDialectRecorder("postgres")
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(db).Table("orders").
+			SelectRaw("SUM(price * ?) AS total", 2).
+			SelectSub(NewBuilder(db).Table("users").Select("name").WhereRaw("users.id = orders.user_id").Where("status", 1), "user_name").
+			Where("status", "paid").
+			GroupByRaw("date_trunc(?, created_at)", "month").
+			HavingRaw("SUM(price) > ?", 100).
+			OrderByRaw("array_position(?, status)", "{paid,pending}").
+			Find(&dest)
+		assert.Nil(t, err)
+		assert.Equal(t, `SELECT SUM(price * $1) AS total,(SELECT "name" FROM "users" WHERE users.id = orders.user_id AND "status" = $2 ) AS "user_name" FROM "orders" WHERE "status" = $3 GROUP BY date_trunc($4, created_at) HAVING SUM(price) > $5 ORDER BY array_position($6, status)`, normalizeSQL(recorder.Last().SQL))
+		assert.Equal(t, []any{2, 1, "paid", "month", 100, "{paid,pending}"}, recorder.Last().Vars)
+	})
+
+	t.Run("raw orders after set operations", func(t *testing.T) {
+		db, recorder := newDialectRecorder("postgres")
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(db).Table("users").Where("status", 1).
+			Union(NewBuilder(db).Table("admins").Where("status", 2)).
+			OrderByRaw("array_position(?, role)", "{owner,admin}").
+			Find(&dest)
+		assert.Nil(t, err)
+		assert.Equal(t, `SELECT * FROM "users" WHERE "status" = $1 UNION SELECT * FROM "admins" WHERE "status" = $2 ORDER BY array_position($3, role)`, normalizeSQL(recorder.Last().SQL))
+		assert.Equal(t, []any{1, 2, "{owner,admin}"}, recorder.Last().Vars)
+	})
+}
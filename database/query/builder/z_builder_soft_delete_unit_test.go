@@ -0,0 +1,93 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/database/exception"
+	"gorm.io/gorm/clause"
+)
+
+func TestBuilder_SoftDeletes(t *testing.T) {
+	RegisterSoftDeletes("articles")
+	RegisterSoftDeletes("comments", "removed_at")
+	defer RemoveSoftDeletes("articles")
+	defer RemoveSoftDeletes("comments")
+
+	t.Run("Builder.SoftDeletes query", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `articles` WHERE `id` = ? AND `articles`.`deleted_at` IS NULL").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "article"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("articles").Where("id", 1).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.SoftDeletes query with alias", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `articles` `a` WHERE `a`.`deleted_at` IS NULL").WithoutArgs().WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "article"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table(clause.Table{Name: "articles", Alias: "a"}).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.SoftDeletes custom column", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `comments` WHERE `comments`.`removed_at` IS NULL").WithoutArgs().WillReturnRows(sqlmock.NewRows([]string{"id", "content"}).AddRow(1, "comment"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("comments").Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.SoftDeletes join", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `articles` INNER JOIN comments c ON c.article_id = articles.id AND `c`.`removed_at` IS NULL WHERE `articles`.`deleted_at` IS NULL").WithoutArgs().WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "article"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("articles").InnerJoin("comments c", "c.article_id = articles.id").Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.WithTrashed", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `articles` INNER JOIN comments ON comments.article_id = articles.id").WithoutArgs().WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "article"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("articles").InnerJoin("comments", "comments.article_id = articles.id").WithTrashed().Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.OnlyTrashed", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `articles` WHERE `articles`.`deleted_at` IS NOT NULL").WithoutArgs().WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "article"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("articles").OnlyTrashed().Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.Delete soft", func(t *testing.T) {
+		mock.ExpectExec("UPDATE `articles` SET `deleted_at`=? WHERE `id` = ? AND `articles`.`deleted_at` IS NULL").WithArgs(sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := NewBuilder(mockDB).Table("articles").Where("id", 1).Delete()
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.Restore", func(t *testing.T) {
+		mock.ExpectExec("UPDATE `articles` SET `deleted_at`=? WHERE `id` = ?").WithArgs(nil, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := NewBuilder(mockDB).Table("articles").Where("id", 1).Restore()
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.ForceDelete", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM `articles` WHERE `id` = ?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := NewBuilder(mockDB).Table("articles").Where("id", 1).ForceDelete()
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.ForceDelete keeps soft deletes of reused builder", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM `articles` WHERE `id` = ?").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT count(*) FROM `articles` WHERE `id` = ? AND `articles`.`deleted_at` IS NULL").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		builder := NewBuilder(mockDB).Table("articles").Where("id", 1)
+		assert.Nil(t, builder.ForceDelete())
+		_, err := builder.Count()
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Builder.Restore without soft deletes", func(t *testing.T) {
+		assert.PanicsWithError(t, exception.NewSoftDeletesNotEnabledErr("users").Error(), func() {
+			_ = NewBuilder(mockDB).Table("users").Restore()
+		})
+	})
+}
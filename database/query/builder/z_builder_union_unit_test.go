@@ -0,0 +1,102 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestBuilder_Union(t *testing.T) {
+	t.Run("Builder.Union", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().Table("users").Where("status", 1).Union(NewBuilder(mockDB).Table("admins").Where("status", 2)).ToSQL()
+		assert.Equal(t, "SELECT * FROM `users` WHERE `status` = 1 UNION SELECT * FROM `admins` WHERE `status` = 2", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.UnionAll", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().Table("users").UnionAll(NewBuilder(mockDB).Table("admins")).ToSQL()
+		assert.Equal(t, "SELECT * FROM `users` UNION ALL SELECT * FROM `admins`", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.Intersect", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().Table("users").Select("id").Intersect(NewBuilder(mockDB).Table("admins").Select("id")).ToSQL()
+		assert.Equal(t, "SELECT `id` FROM `users` INTERSECT SELECT `id` FROM `admins`", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.Except", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().Table("users").Except(NewBuilder(mockDB).Table("admins")).ToSQL()
+		assert.Equal(t, "SELECT * FROM `users` EXCEPT SELECT * FROM `admins`", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.Union multiple", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().Table("users").
+			Union(NewBuilder(mockDB).Table("admins")).
+			UnionAll(mockDB.Table("guests")).
+			ToSQL()
+		assert.Equal(t, "SELECT * FROM `users` UNION SELECT * FROM `admins` UNION ALL SELECT * FROM `guests`", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.Union with callback", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().Table("users").Union(func(tx *gorm.DB) *gorm.DB {
+			return tx.Table("admins")
+		}).ToSQL()
+		assert.Equal(t, "SELECT * FROM `users` UNION SELECT * FROM `admins`", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.Union order and limit", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().Table("users").Union(NewBuilder(mockDB).Table("admins")).OrderDesc("id").Limit(10).Offset(5).ToSQL()
+		assert.Equal(t, "SELECT * FROM `users` UNION SELECT * FROM `admins` ORDER BY `id` DESC LIMIT 10 OFFSET 5", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.Union subquery with order and limit", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().Table("users").Union(NewBuilder(mockDB).Table("admins").OrderDesc("id").Limit(3)).ToSQL()
+		assert.Equal(t, "SELECT * FROM `users` UNION (SELECT * FROM `admins` ORDER BY `id` DESC LIMIT 3)", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.Union bindings", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `status` = ? UNION SELECT * FROM `admins` WHERE `role` IN (?,?) ORDER BY `id` LIMIT ?").WithArgs(1, "root", "owner", 5).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1"))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").Where("status", 1).
+			Union(NewBuilder(mockDB).Table("admins").WhereIn("role", "root", "owner")).
+			OrderAsc("id").
+			Limit(5).
+			Find(&dest)
+		assert.Nil(t, err)
+		assert.Len(t, dest, 1)
+	})
+
+	t.Run("Builder.Union built twice", func(t *testing.T) {
+		builder := NewBuilder(mockDB).DryRun().Table("users").Union(NewBuilder(mockDB).Table("admins")).OrderDesc("id")
+		assert.Equal(t, "SELECT * FROM `users` UNION SELECT * FROM `admins` ORDER BY `id` DESC", normalizeSQL(builder.ToSQL()))
+		assert.Equal(t, "SELECT * FROM `users` UNION SELECT * FROM `admins` ORDER BY `id` DESC", normalizeSQL(builder.ToSQL()))
+	})
+
+	t.Run("Builder.Union raw order built twice", func(t *testing.T) {
+		builder := NewBuilder(mockDB).DryRun().Table("users").Union(NewBuilder(mockDB).Table("admins")).OrderByRaw("FIELD(`role`, ?, ?)", "owner", "admin")
+		assert.Equal(t, "SELECT * FROM `users` UNION SELECT * FROM `admins` ORDER BY FIELD(`role`, 'owner', 'admin')", normalizeSQL(builder.ToSQL()))
+		assert.Equal(t, "SELECT * FROM `users` UNION SELECT * FROM `admins` ORDER BY FIELD(`role`, 'owner', 'admin')", normalizeSQL(builder.ToSQL()))
+	})
+
+	t.Run("Builder.Union count", func(t *testing.T) {
+		mock.ExpectQuery("SELECT count(*) FROM (SELECT * FROM `users` WHERE `status` = ? UNION SELECT * FROM `admins` WHERE `status` = ? ) AS `aggregate`").WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		count, err := NewBuilder(mockDB).Table("users").Where("status", 1).
+			Union(NewBuilder(mockDB).Table("admins").Where("status", 2)).
+			Count()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Builder.Union invalid query", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewBuilder(mockDB).Table("users").Union("admins")
+		})
+	})
+}
+
+// normalizeSQL collapses the redundant spaces left by empty clauses
+func normalizeSQL(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
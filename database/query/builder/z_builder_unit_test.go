@@ -3,12 +3,18 @@ package builder
 import (
 	"database/sql"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
 )
 
 var (
@@ -54,3 +60,118 @@ func TestMain(m *testing.M) {
 	})
 	os.Exit(m.Run())
 }
+
+// dialector renders sql of other dialects in dry run mode
+type dialector struct {
+	name string
+}
+
+func (d dialector) Name() string {
+	return d.name
+}
+
+func (d dialector) Initialize(db *gorm.DB) error {
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	return nil
+}
+
+func (d dialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return nil
+}
+
+func (d dialector) DataTypeOf(field *schema.Field) string {
+	return string(field.DataType)
+}
+
+func (d dialector) DefaultValueOf(field *schema.Field) clause.Expression {
+	return clause.Expr{SQL: "DEFAULT"}
+}
+
+func (d dialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v any) {
+	if d.name == "postgres" {
+		writer.WriteString("$" + strconv.Itoa(len(stmt.Vars)))
+		return
+	}
+	writer.WriteByte('?')
+}
+
+func (d dialector) QuoteTo(writer clause.Writer, str string) {
+	quote := "`"
+	if d.name == "postgres" {
+		quote = `"`
+	}
+	for idx, part := range strings.Split(str, ".") {
+		if idx > 0 {
+			writer.WriteByte('.')
+		}
+		if part == "*" {
+			writer.WriteString(part)
+			continue
+		}
+		writer.WriteString(quote + strings.Trim(part, "`\"") + quote)
+	}
+}
+
+func (d dialector) Explain(sql string, vars ...any) string {
+	if d.name == "postgres" {
+		return logger.ExplainSQL(sql, regexp.MustCompile(`\$(\d+)`), `'`, vars...)
+	}
+	return logger.ExplainSQL(sql, nil, `'`, vars...)
+}
+
+// newDialectDB creates a dry run db of the dialect, e.g. postgres, sqlite
+func newDialectDB(name string) *gorm.DB {
+	db, err := gorm.Open(dialector{name: name}, &gorm.Config{
+		DryRun:                 true,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
+// newDialectRecorder creates a dry run db of the dialect which records the generated statements,
+// subqueries built in dry run are recorded as well, so the executed statement is the last one
+func newDialectRecorder(name string) (*gorm.DB, *statementRecorder) {
+	db := newDialectDB(name)
+	recorder := new(statementRecorder)
+	record := func(tx *gorm.DB) {
+		recorder.statements = append(recorder.statements, recordedStatement{
+			SQL:  tx.Statement.SQL.String(),
+			Vars: append([]any(nil), tx.Statement.Vars...),
+		})
+	}
+	callback := db.Callback()
+	for _, err := range []error{
+		callback.Create().After("*").Register("test:record", record),
+		callback.Query().After("*").Register("test:record", record),
+		callback.Update().After("*").Register("test:record", record),
+		callback.Delete().After("*").Register("test:record", record),
+		callback.Raw().After("*").Register("test:record", record),
+		callback.Row().After("*").Register("test:record", record),
+	} {
+		if err != nil {
+			panic(err)
+		}
+	}
+	return db, recorder
+}
+
+type recordedStatement struct {
+	SQL  string
+	Vars []any
+}
+
+type statementRecorder struct {
+	statements []recordedStatement
+}
+
+// Last returns the last recorded statement
+func (recorder *statementRecorder) Last() recordedStatement {
+	if len(recorder.statements) == 0 {
+		return recordedStatement{}
+	}
+	return recorder.statements[len(recorder.statements)-1]
+}
@@ -28,3 +28,57 @@ func TestBuilder_Update(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestBuilder_Increment(t *testing.T) {
+	t.Run("Builder.Increment", func(t *testing.T) {
+		mock.ExpectExec("UPDATE `posts` SET `views`=`views` + ? WHERE `id` = ?").WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := NewBuilder(mockDB).Table("posts").Where("id", 1).Increment("views", 1)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.Increment extra", func(t *testing.T) {
+		mock.ExpectExec("UPDATE `posts` SET `status`=?,`views`=`views` + ? WHERE `id` = ?").WithArgs("hot", 5, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := NewBuilder(mockDB).Table("posts").Where("id", 1).Increment("views", 5, map[string]any{"status": "hot"})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.Decrement", func(t *testing.T) {
+		mock.ExpectExec("UPDATE `products` SET `stock`=`stock` - ? WHERE `id` = ?").WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := NewBuilder(mockDB).Table("products").Where("id", 1).Decrement("stock", 2)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Builder.Decrement postgres", func(t *testing.T) {
+		db, recorder := newDialectRecorder("postgres")
+		err := NewBuilder(db).Table("products").Where("id", 1).Decrement("stock", 2)
+		assert.Nil(t, err)
+		assert.Equal(t, `UPDATE "products" SET "stock"="stock" - $1 WHERE "id" = $2`, recorder.Last().SQL)
+	})
+}
+
+func TestBuilder_UpdateFrom(t *testing.T) {
+	t.Run("Builder.UpdateFrom mysql", func(t *testing.T) {
+		mock.ExpectExec("UPDATE `users` INNER JOIN orders ON orders.user_id = users.id SET `users`.`level`=? WHERE `orders`.`status` = ?").WithArgs(2, "paid").WillReturnResult(sqlmock.NewResult(0, 1))
+		err := NewBuilder(mockDB).Table("users").InnerJoin("orders", "orders.user_id = users.id").Where("orders.status", "paid").UpdateFrom(map[string]any{"users.level": 2})
+		assert.Nil(t, err)
+	})
+
+	for name, expected := range map[string]string{
+		"postgres": `UPDATE "users" SET "level"=$1 FROM orders WHERE orders.user_id = users.id AND "orders"."status" = $2`,
+		"sqlite":   "UPDATE `users` SET `level`=? FROM orders WHERE orders.user_id = users.id AND `orders`.`status` = ?",
+	} {
+		t.Run("Builder.UpdateFrom "+name, func(t *testing.T) {
+			db, recorder := newDialectRecorder(name)
+			err := NewBuilder(db).Table("users").InnerJoin("orders", "orders.user_id = users.id").Where("orders.status", "paid").UpdateFrom(map[string]any{"users.level": 2})
+			assert.Nil(t, err)
+			assert.Equal(t, expected, recorder.Last().SQL)
+			assert.Equal(t, []any{2, "paid"}, recorder.Last().Vars)
+		})
+	}
+
+	t.Run("Builder.UpdateFrom without joins", func(t *testing.T) {
+		mock.ExpectExec("UPDATE `users` SET `level`=? WHERE `id` = ?").WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := NewBuilder(mockDB).Table("users").Where("id", 1).UpdateFrom(map[string]any{"level": 2})
+		assert.Nil(t, err)
+	})
+}
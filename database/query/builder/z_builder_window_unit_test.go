@@ -0,0 +1,79 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	queryclause "github.com/wardonne/gopi/database/query/clause"
+	"gorm.io/gorm/clause"
+)
+
+func TestBuilder_SelectWindow(t *testing.T) {
+	t.Run("Builder.SelectWindow row number", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().Table("employees").Select("id").SelectWindow("rank",
+			queryclause.RowNumber(),
+			[]string{"department_id"},
+			[]clause.OrderByColumn{{Column: clause.Column{Name: "salary"}, Desc: true}},
+			nil,
+		).ToSQL()
+		assert.Equal(t, "SELECT `id`,ROW_NUMBER() OVER (PARTITION BY `department_id` ORDER BY `salary` DESC) AS `rank` FROM `employees`", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.SelectWindow lag", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().Table("prices").Select("day").SelectWindow("previous",
+			queryclause.Lag("price", 1, 0),
+			nil,
+			[]clause.OrderByColumn{{Column: clause.Column{Name: "day"}}},
+			nil,
+		).ToSQL()
+		assert.Equal(t, "SELECT `day`,LAG(`price`, 1, 0) OVER (ORDER BY `day`) AS `previous` FROM `prices`", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.SelectWindow running sum", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().Table("orders").Select("id").SelectWindow("running_total",
+			queryclause.Sum("amount"),
+			[]string{"user_id"},
+			[]clause.OrderByColumn{{Column: clause.Column{Name: "created_at"}}},
+			&queryclause.Frame{Start: queryclause.UnboundedPreceding, End: queryclause.CurrentRow},
+		).ToSQL()
+		assert.Equal(t, "SELECT `id`,SUM(`amount`) OVER (PARTITION BY `user_id` ORDER BY `created_at` ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS `running_total` FROM `orders`", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.SelectWindow frame only", func(t *testing.T) {
+		sql := NewBuilder(mockDB).DryRun().Table("orders").SelectWindow("moving_avg",
+			queryclause.Avg("amount"),
+			nil,
+			nil,
+			&queryclause.Frame{Mode: queryclause.FrameRange, Start: queryclause.Preceding(2), End: queryclause.Following(1)},
+		).ToSQL()
+		assert.Equal(t, "SELECT AVG(`amount`) OVER (RANGE BETWEEN 2 PRECEDING AND 1 FOLLOWING) AS `moving_avg` FROM `orders`", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.SelectWindow postgres", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("postgres")).Table("orders").Select("id").SelectWindow("running_total",
+			queryclause.Sum("amount"),
+			[]string{"orders.user_id"},
+			[]clause.OrderByColumn{{Column: clause.Column{Name: "created_at"}, Desc: true}},
+			&queryclause.Frame{Mode: queryclause.FrameGroups, Start: queryclause.Preceding(1), End: queryclause.CurrentRow},
+		).Where("status", "paid").ToSQL()
+		assert.Equal(t, `SELECT "id",SUM("amount") OVER (PARTITION BY "orders"."user_id" ORDER BY "created_at" DESC GROUPS BETWEEN 1 PRECEDING AND CURRENT ROW) AS "running_total" FROM "orders" WHERE "status" = 'paid'`, normalizeSQL(sql))
+	})
+
+	t.Run("Builder.SelectWindow sqlite", func(t *testing.T) {
+		sql := NewBuilder(newDialectDB("sqlite")).Table("prices").Select("day").SelectWindow("previous",
+			queryclause.Lag("price", 1, 0),
+			nil,
+			[]clause.OrderByColumn{{Column: clause.Column{Name: "day"}}},
+			&queryclause.Frame{Mode: queryclause.FrameRows, Start: queryclause.Preceding(3)},
+		).ToSQL()
+		assert.Equal(t, "SELECT `day`,LAG(`price`, 1, 0) OVER (ORDER BY `day` ROWS 3 PRECEDING) AS `previous` FROM `prices`", normalizeSQL(sql))
+	})
+
+	t.Run("Builder.SelectWindow query", func(t *testing.T) {
+		mock.ExpectQuery("SELECT `id`,COUNT(*) OVER (PARTITION BY `status`) AS `total` FROM `users` WHERE `status` = ?").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id", "total"}).AddRow(1, 1))
+		var dest = make([]map[string]any, 0)
+		err := NewBuilder(mockDB).Table("users").Select("id").SelectWindow("total", queryclause.Count("*"), []string{"status"}, nil, nil).Where("status", 1).Find(&dest)
+		assert.Nil(t, err)
+	})
+}
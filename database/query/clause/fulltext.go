@@ -0,0 +1,131 @@
+package clause
+
+import (
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// FullTextMode full-text search mode
+type FullTextMode string
+
+// Full-text search modes
+const (
+	// FullTextNatural natural language search
+	//
+	//	MySQL: IN NATURAL LANGUAGE MODE
+	//	PostgreSQL: plainto_tsquery
+	FullTextNatural FullTextMode = "natural"
+	// FullTextBoolean search with operators
+	//
+	//	MySQL: IN BOOLEAN MODE
+	//	PostgreSQL: to_tsquery
+	FullTextBoolean FullTextMode = "boolean"
+	// FullTextQueryExpansion natural language search with query expansion, only supported by MySQL
+	//
+	//	MySQL: WITH QUERY EXPANSION
+	//	PostgreSQL: plainto_tsquery
+	FullTextQueryExpansion FullTextMode = "expansion"
+)
+
+// FullText full-text search condition
+//
+//	MySQL: MATCH (`title`,`body`) AGAINST (? IN NATURAL LANGUAGE MODE)
+//	PostgreSQL: to_tsvector(coalesce("title", '') || ' ' || coalesce("body", '')) @@ plainto_tsquery(?)
+//	SQLite: `posts` MATCH '{title body}: (query)', it requires an FTS5 virtual table
+type FullText struct {
+	// Table quoted table, used by SQLite when searching multiple columns
+	Table string
+	// Columns quoted columns
+	Columns []string
+	Query   string
+	Mode    FullTextMode
+}
+
+// Build build full-text search condition
+func (fullText FullText) Build(builder clause.Builder) {
+	switch Dialect(builder) {
+	case Postgres:
+		fullText.buildTSVector(builder)
+		builder.WriteString(" @@ ")
+		fullText.buildTSQuery(builder)
+	case SQLite:
+		if len(fullText.Columns) == 1 {
+			builder.WriteString(fullText.Columns[0] + " MATCH ")
+			builder.AddVar(builder, fullText.Query)
+			return
+		}
+		columns := make([]string, 0, len(fullText.Columns))
+		for _, column := range fullText.Columns {
+			columns = append(columns, strings.Trim(column, "`\""))
+		}
+		builder.WriteString(fullText.Table + " MATCH ")
+		builder.AddVar(builder, "{"+strings.Join(columns, " ")+"}: ("+fullText.Query+")")
+	default:
+		fullText.buildMatch(builder)
+	}
+}
+
+func (fullText FullText) buildMatch(builder clause.Builder) {
+	builder.WriteString("MATCH (" + strings.Join(fullText.Columns, ",") + ") AGAINST (")
+	builder.AddVar(builder, fullText.Query)
+	switch fullText.Mode {
+	case FullTextBoolean:
+		builder.WriteString(" IN BOOLEAN MODE)")
+	case FullTextQueryExpansion:
+		builder.WriteString(" WITH QUERY EXPANSION)")
+	default:
+		builder.WriteString(" IN NATURAL LANGUAGE MODE)")
+	}
+}
+
+func (fullText FullText) buildTSVector(builder clause.Builder) {
+	builder.WriteString("to_tsvector(")
+	if len(fullText.Columns) == 1 {
+		builder.WriteString(fullText.Columns[0])
+	} else {
+		for idx, column := range fullText.Columns {
+			if idx > 0 {
+				builder.WriteString(" || ' ' || ")
+			}
+			builder.WriteString("coalesce(" + column + ", '')")
+		}
+	}
+	builder.WriteByte(')')
+}
+
+func (fullText FullText) buildTSQuery(builder clause.Builder) {
+	if fullText.Mode == FullTextBoolean {
+		builder.WriteString("to_tsquery(")
+	} else {
+		builder.WriteString("plainto_tsquery(")
+	}
+	builder.AddVar(builder, fullText.Query)
+	builder.WriteByte(')')
+}
+
+// FullTextRelevance orders by the relevance of the full-text search, the most relevant first
+//
+//	MySQL: MATCH (`title`,`body`) AGAINST (? IN NATURAL LANGUAGE MODE) DESC
+//	PostgreSQL: ts_rank(to_tsvector("title"), plainto_tsquery(?)) DESC
+//	SQLite: bm25(`posts`)
+type FullTextRelevance struct {
+	FullText FullText
+}
+
+// Build build full-text relevance order
+func (relevance FullTextRelevance) Build(builder clause.Builder) {
+	switch Dialect(builder) {
+	case Postgres:
+		builder.WriteString("ts_rank(")
+		relevance.FullText.buildTSVector(builder)
+		builder.WriteString(", ")
+		relevance.FullText.buildTSQuery(builder)
+		builder.WriteString(") DESC")
+	case SQLite:
+		builder.WriteString("bm25(" + relevance.FullText.Table + ")")
+	default:
+		relevance.FullText.buildMatch(builder)
+		builder.WriteString(" DESC")
+	}
+}
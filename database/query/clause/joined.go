@@ -0,0 +1,138 @@
+package clause
+
+import (
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// JoinedUpdate updates the current table with joined tables
+//
+// # NOTICE: PostgreSQL and SQLite join the tables in the FROM clause, all joins are treated as inner joins
+//
+//	MySQL: UPDATE `users` INNER JOIN orders ON orders.user_id = users.id SET `users`.`status`=? WHERE ...
+//	PostgreSQL: UPDATE "users" SET "status"=$1 FROM orders WHERE orders.user_id = users.id AND ...
+//	SQLite: UPDATE `users` SET `status`=? FROM orders WHERE orders.user_id = users.id AND ...
+type JoinedUpdate struct {
+	Joins []clause.Join
+	Set   clause.Set
+	Where clause.Where
+}
+
+// Build build joined update
+func (update JoinedUpdate) Build(builder clause.Builder) {
+	builder.WriteString("UPDATE ")
+	builder.WriteQuoted(clause.Table{Name: clause.CurrentTable})
+	switch Dialect(builder) {
+	case Postgres, SQLite:
+		builder.WriteString(" SET ")
+		unqualify(update.Set).Build(builder)
+		builder.WriteString(" FROM ")
+		writeJoinedTables(builder, update.Joins)
+		writeJoinedWhere(builder, update.Joins, update.Where)
+	default:
+		for _, join := range update.Joins {
+			builder.WriteByte(' ')
+			join.Build(builder)
+		}
+		builder.WriteString(" SET ")
+		update.Set.Build(builder)
+		if len(update.Where.Exprs) > 0 {
+			builder.WriteString(" WHERE ")
+			update.Where.Build(builder)
+		}
+	}
+}
+
+// JoinedDelete deletes records of the current table matched with joined tables
+//
+// # NOTICE: PostgreSQL joins the tables in the USING clause, all joins are treated as inner joins
+//
+//	MySQL: DELETE `users` FROM `users` INNER JOIN orders ON orders.user_id = users.id WHERE ...
+//	PostgreSQL: DELETE FROM "users" USING orders WHERE orders.user_id = users.id AND ...
+//	SQLite: DELETE FROM `users` WHERE rowid IN (SELECT `users`.rowid FROM `users` INNER JOIN orders ON ... WHERE ...)
+type JoinedDelete struct {
+	// Target the name or alias of the current table
+	Target string
+	Joins  []clause.Join
+	Where  clause.Where
+}
+
+// Build build joined delete
+func (remove JoinedDelete) Build(builder clause.Builder) {
+	switch Dialect(builder) {
+	case Postgres:
+		builder.WriteString("DELETE FROM ")
+		builder.WriteQuoted(clause.Table{Name: clause.CurrentTable})
+		builder.WriteString(" USING ")
+		writeJoinedTables(builder, remove.Joins)
+		writeJoinedWhere(builder, remove.Joins, remove.Where)
+	case SQLite:
+		builder.WriteString("DELETE FROM ")
+		builder.WriteQuoted(clause.Table{Name: clause.CurrentTable})
+		builder.WriteString(" WHERE rowid IN (SELECT ")
+		builder.WriteQuoted(remove.Target)
+		builder.WriteString(".rowid FROM ")
+		remove.writeFrom(builder)
+		builder.WriteByte(')')
+	default:
+		builder.WriteString("DELETE ")
+		builder.WriteQuoted(remove.Target)
+		builder.WriteString(" FROM ")
+		remove.writeFrom(builder)
+	}
+}
+
+func (remove JoinedDelete) writeFrom(builder clause.Builder) {
+	builder.WriteQuoted(clause.Table{Name: clause.CurrentTable})
+	for _, join := range remove.Joins {
+		builder.WriteByte(' ')
+		join.Build(builder)
+	}
+	if len(remove.Where.Exprs) > 0 {
+		builder.WriteString(" WHERE ")
+		remove.Where.Build(builder)
+	}
+}
+
+func writeJoinedTables(builder clause.Builder, joins []clause.Join) {
+	for idx, join := range joins {
+		if idx > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteQuoted(join.Table)
+	}
+}
+
+// writeJoinedWhere writes the join conditions followed by the where conditions
+func writeJoinedWhere(builder clause.Builder, joins []clause.Join, where clause.Where) {
+	exprs := make([]clause.Expression, 0, len(joins)+1)
+	for _, join := range joins {
+		if len(join.ON.Exprs) > 0 {
+			exprs = append(exprs, clause.And(join.ON.Exprs...))
+		}
+	}
+	if len(where.Exprs) > 0 {
+		exprs = append(exprs, clause.And(where.Exprs...))
+	}
+	if len(exprs) == 0 {
+		return
+	}
+	builder.WriteString(" WHERE ")
+	clause.Where{Exprs: exprs}.Build(builder)
+}
+
+// unqualify removes the table of the assigned columns, which is not allowed in the SET clause of PostgreSQL and SQLite
+func unqualify(set clause.Set) clause.Set {
+	assignments := make(clause.Set, 0, len(set))
+	for _, assignment := range set {
+		if !assignment.Column.Raw {
+			assignment.Column.Table = ""
+			if idx := strings.LastIndex(assignment.Column.Name, "."); idx >= 0 {
+				assignment.Column.Name = assignment.Column.Name[idx+1:]
+			}
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments
+}
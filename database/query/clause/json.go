@@ -1,33 +1,350 @@
 package clause
 
 import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// Supported dialects
+const (
+	MySQL    = "mysql"
+	Postgres = "postgres"
+	SQLite   = "sqlite"
+)
+
+// Dialect returns the dialect name of builder, it returns [MySQL] if the dialect is unknown
+func Dialect(builder clause.Builder) string {
+	if stmt, ok := builder.(*gorm.Statement); ok && stmt.Dialector != nil {
+		switch name := stmt.Dialector.Name(); name {
+		case Postgres, SQLite:
+			return name
+		}
+	}
+	return MySQL
+}
+
+// JSONPath splits a path like `meta->address->city` into column `meta` and keys [address city]
+func JSONPath(path string) (string, []string) {
+	parts := strings.Split(path, "->")
+	column := strings.TrimSpace(parts[0])
+	keys := make([]string, 0, len(parts)-1)
+	for _, key := range parts[1:] {
+		keys = append(keys, strings.Trim(strings.TrimSpace(key), `'">`))
+	}
+	return column, keys
+}
+
+// JSONKeysPath converts keys into a MySQL/SQLite json path
+//
+//	JSONKeysPath([]string{"address", "city"}) // $."address"."city"
+//	JSONKeysPath([]string{"tags", "0"}) // $."tags"[0]
+func JSONKeysPath(keys []string) string {
+	var path strings.Builder
+	path.WriteByte('$')
+	for _, key := range keys {
+		if _, err := strconv.Atoi(key); err == nil {
+			path.WriteString("[" + key + "]")
+		} else {
+			path.WriteString(`."` + strings.ReplaceAll(key, `"`, `\"`) + `"`)
+		}
+	}
+	return path.String()
+}
+
+// PostgresKeysPath converts keys into a PostgreSQL text array path
+//
+//	PostgresKeysPath([]string{"address", "city"}) // {address,city}
+func PostgresKeysPath(keys []string) string {
+	return "{" + strings.Join(keys, ",") + "}"
+}
+
+func marshalJSON(value any) string {
+	bytes, _ := json.Marshal(value)
+	return string(bytes)
+}
+
+func isComposite(value any) bool {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Struct, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// JSONContainsPath checks whether the json column contains the paths
+//
+// paths are written in MySQL json path syntax such as `$.department.status`
+//
+//	MySQL: JSON_CONTAINS_PATH (`meta`, 'one', '$.a', '$.b')
+//	PostgreSQL: (jsonb_path_exists("meta"::jsonb, '$.a') OR jsonb_path_exists("meta"::jsonb, '$.b'))
+//	SQLite: (json_type(`meta`, '$.a') IS NOT NULL OR json_type(`meta`, '$.b') IS NOT NULL)
 type JSONContainsPath struct {
 	Column string
 	Pathes []string
 	All    bool
 }
 
+// Build build json contains path
 func (json JSONContainsPath) Build(builder clause.Builder) {
-	if stmt, ok := builder.(*gorm.Statement); ok {
-		switch stmt.Dialector.Name() {
-		case "mysql":
-			builder.WriteString("JSON_CONTAINS_PATH (")
-			builder.WriteString(stmt.Quote(json.Column))
-			builder.WriteString(", ")
-			if json.All {
-				builder.AddVar(builder, "all")
-			} else {
-				builder.AddVar(builder, "one")
+	switch Dialect(builder) {
+	case Postgres, SQLite:
+		join := " OR "
+		if json.All {
+			join = " AND "
+		}
+		if len(json.Pathes) > 1 {
+			builder.WriteByte('(')
+		}
+		for idx, path := range json.Pathes {
+			if idx > 0 {
+				builder.WriteString(join)
 			}
-			builder.WriteString(", ")
-			for _, path := range json.Pathes {
+			if Dialect(builder) == Postgres {
+				builder.WriteString("jsonb_path_exists(" + json.Column + "::jsonb, ")
 				builder.AddVar(builder, path)
+				builder.WriteByte(')')
+			} else {
+				builder.WriteString("json_type(" + json.Column + ", ")
+				builder.AddVar(builder, path)
+				builder.WriteString(") IS NOT NULL")
 			}
+		}
+		if len(json.Pathes) > 1 {
 			builder.WriteByte(')')
 		}
+	default:
+		builder.WriteString("JSON_CONTAINS_PATH (")
+		builder.WriteString(json.Column)
+		builder.WriteString(", ")
+		if json.All {
+			builder.AddVar(builder, "all")
+		} else {
+			builder.AddVar(builder, "one")
+		}
+		for _, path := range json.Pathes {
+			builder.WriteString(", ")
+			builder.AddVar(builder, path)
+		}
+		builder.WriteByte(')')
+	}
+}
+
+// JSONContains checks whether the json array column contains the value
+//
+//	MySQL: JSON_CONTAINS (`tags`, JSON_ARRAY(?))
+//	PostgreSQL: "tags"::jsonb @> ?::jsonb
+//	SQLite: EXISTS (SELECT 1 FROM json_each(`tags`) WHERE json_each.value = ?)
+type JSONContains struct {
+	Column string
+	Value  any
+}
+
+// Build build json contains
+func (json JSONContains) Build(builder clause.Builder) {
+	switch Dialect(builder) {
+	case Postgres:
+		builder.WriteString(json.Column + "::jsonb @> ")
+		builder.AddVar(builder, marshalJSON([]any{json.Value}))
+		builder.WriteString("::jsonb")
+	case SQLite:
+		builder.WriteString("EXISTS (SELECT 1 FROM json_each(" + json.Column + ") WHERE json_each.value = ")
+		builder.AddVar(builder, json.Value)
+		builder.WriteByte(')')
+	default:
+		builder.WriteString("JSON_CONTAINS (" + json.Column + ", JSON_ARRAY(")
+		builder.AddVar(builder, json.Value)
+		builder.WriteString("))")
+	}
+}
+
+// JSONOverlaps checks whether the json array column has any element of the json array value
+//
+// # NOTICE: PostgreSQL compares the elements as text by `?|`
+//
+//	MySQL: JSON_OVERLAPS(`tags`,?)
+//	PostgreSQL: "tags"::jsonb ?| ARRAY(SELECT jsonb_array_elements_text(?::jsonb))
+//	SQLite: EXISTS (SELECT 1 FROM json_each(`tags`) AS a, json_each(?) AS b WHERE a.value = b.value)
+type JSONOverlaps struct {
+	Column string
+	Value  string
+}
+
+// Build build json overlaps
+func (json JSONOverlaps) Build(builder clause.Builder) {
+	switch Dialect(builder) {
+	case Postgres:
+		builder.WriteString(json.Column + "::jsonb ?| ARRAY(SELECT jsonb_array_elements_text(")
+		builder.AddVar(builder, json.Value)
+		builder.WriteString("::jsonb))")
+	case SQLite:
+		builder.WriteString("EXISTS (SELECT 1 FROM json_each(" + json.Column + ") AS a, json_each(")
+		builder.AddVar(builder, json.Value)
+		builder.WriteString(") AS b WHERE a.value = b.value)")
+	default:
+		builder.WriteString("JSON_OVERLAPS(" + json.Column + ",")
+		builder.AddVar(builder, json.Value)
+		builder.WriteByte(')')
+	}
+}
+
+// JSONExtract extracts the value at keys from the json column as an unquoted scalar
+//
+//	MySQL: JSON_UNQUOTE(JSON_EXTRACT(`meta`, '$."address"."city"'))
+//	PostgreSQL: ("meta"::jsonb #>> '{address,city}')
+//	SQLite: json_extract(`meta`, '$."address"."city"')
+type JSONExtract struct {
+	Column string
+	Keys   []string
+	// Cast casts the extracted value for PostgreSQL, e.g. numeric, boolean
+	Cast string
+}
+
+// Build build json extract
+func (json JSONExtract) Build(builder clause.Builder) {
+	switch Dialect(builder) {
+	case Postgres:
+		builder.WriteString("(" + json.Column + "::jsonb #>> ")
+		builder.AddVar(builder, PostgresKeysPath(json.Keys))
+		builder.WriteByte(')')
+		if json.Cast != "" {
+			builder.WriteString("::" + json.Cast)
+		}
+	case SQLite:
+		builder.WriteString("json_extract(" + json.Column + ", ")
+		builder.AddVar(builder, JSONKeysPath(json.Keys))
+		builder.WriteByte(')')
+	default:
+		if json.Cast == "boolean" {
+			builder.WriteString("JSON_EXTRACT(" + json.Column + ", ")
+			builder.AddVar(builder, JSONKeysPath(json.Keys))
+			builder.WriteByte(')')
+			return
+		}
+		builder.WriteString("JSON_UNQUOTE(JSON_EXTRACT(" + json.Column + ", ")
+		builder.AddVar(builder, JSONKeysPath(json.Keys))
+		builder.WriteString("))")
+	}
+}
+
+// JSONCompare compares the value at keys of the json column
+//
+//	JSONCompare{Column: "`meta`", Keys: []string{"address", "city"}, Operator: "=", Value: "Paris"}
+//	// MySQL: JSON_UNQUOTE(JSON_EXTRACT(`meta`, '$."address"."city"')) = 'Paris'
+type JSONCompare struct {
+	Column   string
+	Keys     []string
+	Operator string
+	Value    any
+}
+
+// Build build json compare
+func (json JSONCompare) Build(builder clause.Builder) {
+	extract := JSONExtract{Column: json.Column, Keys: json.Keys}
+	switch json.Value.(type) {
+	case bool:
+		extract.Cast = "boolean"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		extract.Cast = "numeric"
+	}
+	extract.Build(builder)
+	builder.WriteString(" " + json.Operator + " ")
+	if value, ok := json.Value.(bool); ok && Dialect(builder) != Postgres {
+		builder.WriteString(strconv.FormatBool(value))
+		return
+	}
+	builder.AddVar(builder, json.Value)
+}
+
+// JSONLength compares the length of the json array at keys of the json column
+//
+//	MySQL: JSON_LENGTH(`tags`) > ?
+//	PostgreSQL: jsonb_array_length("tags"::jsonb) > ?
+//	SQLite: json_array_length(`tags`) > ?
+type JSONLength struct {
+	Column   string
+	Keys     []string
+	Operator string
+	Value    any
+}
+
+// Build build json length
+func (json JSONLength) Build(builder clause.Builder) {
+	switch Dialect(builder) {
+	case Postgres:
+		builder.WriteString("jsonb_array_length(" + json.Column + "::jsonb")
+		if len(json.Keys) > 0 {
+			builder.WriteString(" #> ")
+			builder.AddVar(builder, PostgresKeysPath(json.Keys))
+		}
+	case SQLite:
+		builder.WriteString("json_array_length(" + json.Column)
+		if len(json.Keys) > 0 {
+			builder.WriteString(", ")
+			builder.AddVar(builder, JSONKeysPath(json.Keys))
+		}
+	default:
+		builder.WriteString("JSON_LENGTH(" + json.Column)
+		if len(json.Keys) > 0 {
+			builder.WriteString(", ")
+			builder.AddVar(builder, JSONKeysPath(json.Keys))
+		}
+	}
+	builder.WriteString(") " + json.Operator + " ")
+	builder.AddVar(builder, json.Value)
+}
+
+// JSONSet sets the value at keys of the json column, composite values are encoded as json
+//
+//	MySQL: JSON_SET(`meta`, '$."address"."city"', ?)
+//	PostgreSQL: jsonb_set("meta"::jsonb, '{address,city}', ?::jsonb)
+//	SQLite: json_set(`meta`, '$."address"."city"', ?)
+type JSONSet struct {
+	Column string
+	Keys   []string
+	Value  any
+}
+
+// Build build json set
+func (json JSONSet) Build(builder clause.Builder) {
+	switch Dialect(builder) {
+	case Postgres:
+		builder.WriteString("jsonb_set(" + json.Column + "::jsonb, ")
+		builder.AddVar(builder, PostgresKeysPath(json.Keys))
+		builder.WriteString(", ")
+		builder.AddVar(builder, marshalJSON(json.Value))
+		builder.WriteString("::jsonb")
+	case SQLite:
+		builder.WriteString("json_set(" + json.Column + ", ")
+		builder.AddVar(builder, JSONKeysPath(json.Keys))
+		builder.WriteString(", ")
+		if isComposite(json.Value) {
+			builder.WriteString("json(")
+			builder.AddVar(builder, marshalJSON(json.Value))
+			builder.WriteByte(')')
+		} else {
+			builder.AddVar(builder, json.Value)
+		}
+	default:
+		builder.WriteString("JSON_SET(" + json.Column + ", ")
+		builder.AddVar(builder, JSONKeysPath(json.Keys))
+		builder.WriteString(", ")
+		if isComposite(json.Value) {
+			builder.WriteString("CAST(")
+			builder.AddVar(builder, marshalJSON(json.Value))
+			builder.WriteString(" AS JSON)")
+		} else {
+			builder.AddVar(builder, json.Value)
+		}
 	}
+	builder.WriteByte(')')
 }
@@ -0,0 +1,50 @@
+package clause
+
+import "gorm.io/gorm/clause"
+
+// Lock strengths
+const (
+	LockForUpdate = "UPDATE"
+	LockForShare  = "SHARE"
+)
+
+// Lock options
+const (
+	LockSkipLocked = "SKIP LOCKED"
+	LockNoWait     = "NOWAIT"
+)
+
+// Lock row locking clause
+//
+//	MySQL 8/PostgreSQL: FOR UPDATE SKIP LOCKED, FOR SHARE NOWAIT
+//	SQLite: row locking is not supported, nothing is written
+type Lock struct {
+	Strength string
+	Options  string
+}
+
+// Name implements [clause.Interface].Name
+func (lock Lock) Name() string {
+	return "FOR"
+}
+
+// MergeClause implements [clause.Interface].MergeClause
+func (lock Lock) MergeClause(c *clause.Clause) {
+	c.Name = ""
+	c.Expression = lock
+}
+
+// Build build lock clause
+func (lock Lock) Build(builder clause.Builder) {
+	if Dialect(builder) == SQLite {
+		return
+	}
+	strength := lock.Strength
+	if strength == "" {
+		strength = LockForUpdate
+	}
+	builder.WriteString("FOR " + strength)
+	if lock.Options != "" {
+		builder.WriteString(" " + lock.Options)
+	}
+}
@@ -0,0 +1,72 @@
+package clause
+
+import "gorm.io/gorm/clause"
+
+// RawColumns binds the raw columns of ORDER BY and GROUP BY clauses
+//
+// Raw columns are added to the clauses as raw [clause.Column] and matched by sql when building,
+// columns with the same sql are bound in the order they are added.
+//
+//	raws := RawColumns{}
+//	raws.Add("FIELD(status, ?, ?)", []any{"paid", "pending"})
+//	c := stmt.Clauses["ORDER BY"]
+//	c.Name, c.Builder = "ORDER BY", raws.OrderByBuilder
+//	stmt.Clauses["ORDER BY"] = c
+type RawColumns map[string][][]any
+
+// Add adds the bindings of the raw column sql
+func (raws RawColumns) Add(sql string, vars []any) {
+	raws[sql] = append(raws[sql], vars)
+}
+
+// OrderByBuilder builds the ORDER BY clause with the bindings of raw columns
+func (raws RawColumns) OrderByBuilder(c clause.Clause, builder clause.Builder) {
+	orderBy, ok := c.Expression.(clause.OrderBy)
+	if !ok || orderBy.Expression != nil {
+		c.Builder = nil
+		c.Build(builder)
+		return
+	}
+	builder.WriteString("ORDER BY ")
+	used := make(map[string]int)
+	for idx, column := range orderBy.Columns {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+		raws.build(builder, column.Column, used)
+		if column.Desc {
+			builder.WriteString(" DESC")
+		}
+	}
+}
+
+// GroupByBuilder builds the GROUP BY clause with the bindings of raw columns
+func (raws RawColumns) GroupByBuilder(c clause.Clause, builder clause.Builder) {
+	groupBy, ok := c.Expression.(clause.GroupBy)
+	if !ok || len(groupBy.Columns) == 0 {
+		c.Builder = nil
+		c.Build(builder)
+		return
+	}
+	builder.WriteString("GROUP BY ")
+	used := make(map[string]int)
+	for idx, column := range groupBy.Columns {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+		raws.build(builder, column, used)
+	}
+	if len(groupBy.Having) > 0 {
+		builder.WriteString(" HAVING ")
+		clause.Where{Exprs: groupBy.Having}.Build(builder)
+	}
+}
+
+func (raws RawColumns) build(builder clause.Builder, column clause.Column, used map[string]int) {
+	if bindings := raws[column.Name]; column.Raw && used[column.Name] < len(bindings) {
+		clause.Expr{SQL: column.Name, Vars: bindings[used[column.Name]]}.Build(builder)
+		used[column.Name]++
+		return
+	}
+	builder.WriteQuoted(column)
+}
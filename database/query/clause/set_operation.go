@@ -0,0 +1,81 @@
+package clause
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SetOperator set operator which combines the results of two queries
+type SetOperator string
+
+// Set operators
+const (
+	Union        SetOperator = "UNION"
+	UnionAll     SetOperator = "UNION ALL"
+	Intersect    SetOperator = "INTERSECT"
+	IntersectAll SetOperator = "INTERSECT ALL"
+	Except       SetOperator = "EXCEPT"
+	ExceptAll    SetOperator = "EXCEPT ALL"
+)
+
+// SetOperation combines the result of the query with the operator
+type SetOperation struct {
+	Operator SetOperator
+	Query    *gorm.DB
+}
+
+// Build build set operation
+//
+// the query is wrapped in parentheses if it has its own ORDER BY or LIMIT
+func (operation SetOperation) Build(builder clause.Builder) {
+	builder.WriteString(string(operation.Operator))
+	builder.WriteByte(' ')
+	_, ordered := operation.Query.Statement.Clauses["ORDER BY"]
+	_, limited := operation.Query.Statement.Clauses["LIMIT"]
+	if ordered || limited {
+		builder.WriteByte('(')
+		builder.AddVar(builder, operation.Query)
+		builder.WriteByte(')')
+	} else {
+		builder.AddVar(builder, operation.Query)
+	}
+}
+
+// SetOperations set operations
+type SetOperations []SetOperation
+
+// Build build set operations
+func (operations SetOperations) Build(builder clause.Builder) {
+	for idx, operation := range operations {
+		if idx > 0 {
+			builder.WriteByte(' ')
+		}
+		operation.Build(builder)
+	}
+}
+
+// ClauseBuilder builds the set operations before the ORDER BY clause,
+// so that ORDER BY and LIMIT apply to the combined result
+//
+//	c := stmt.Clauses["ORDER BY"]
+//	c.Name, c.Builder = "ORDER BY", operations.ClauseBuilder
+//	stmt.Clauses["ORDER BY"] = c
+func (operations SetOperations) ClauseBuilder(c clause.Clause, builder clause.Builder) {
+	operations.OrderedBy(func(c clause.Clause, builder clause.Builder) {
+		c.Builder = nil
+		c.Build(builder)
+	})(c, builder)
+}
+
+// OrderedBy works like [SetOperations.ClauseBuilder] but builds the ORDER BY clause by order
+//
+//	c.Builder = operations.OrderedBy(raws.OrderByBuilder)
+func (operations SetOperations) OrderedBy(order clause.ClauseBuilder) clause.ClauseBuilder {
+	return func(c clause.Clause, builder clause.Builder) {
+		operations.Build(builder)
+		if c.Expression != nil {
+			builder.WriteByte(' ')
+			order(c, builder)
+		}
+	}
+}
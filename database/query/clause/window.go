@@ -0,0 +1,205 @@
+package clause
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// FrameMode window frame mode
+type FrameMode string
+
+// Window frame modes
+//
+// # NOTICE: GROUPS is not supported by MySQL
+const (
+	FrameRows   FrameMode = "ROWS"
+	FrameRange  FrameMode = "RANGE"
+	FrameGroups FrameMode = "GROUPS"
+)
+
+// Window frame bounds
+const (
+	UnboundedPreceding = "UNBOUNDED PRECEDING"
+	UnboundedFollowing = "UNBOUNDED FOLLOWING"
+	CurrentRow         = "CURRENT ROW"
+)
+
+// Preceding returns the frame bound `n PRECEDING`
+func Preceding(n int) string {
+	return fmt.Sprintf("%d PRECEDING", n)
+}
+
+// Following returns the frame bound `n FOLLOWING`
+func Following(n int) string {
+	return fmt.Sprintf("%d FOLLOWING", n)
+}
+
+// Frame window frame
+//
+//	Frame{Mode: FrameRows, Start: UnboundedPreceding, End: CurrentRow} // ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW
+//	Frame{Mode: FrameRows, Start: Preceding(2)} // ROWS 2 PRECEDING
+type Frame struct {
+	Mode  FrameMode
+	Start string
+	End   string
+}
+
+// Build build window frame
+func (frame Frame) Build(builder clause.Builder) {
+	mode := frame.Mode
+	if mode == "" {
+		mode = FrameRows
+	}
+	builder.WriteString(string(mode))
+	builder.WriteByte(' ')
+	if frame.End == "" {
+		builder.WriteString(frame.Start)
+		return
+	}
+	builder.WriteString("BETWEEN ")
+	builder.WriteString(frame.Start)
+	builder.WriteString(" AND ")
+	builder.WriteString(frame.End)
+}
+
+// WindowFunction function called over a window
+//
+// string arguments are quoted as columns, integer arguments are written as literals,
+// expressions are built and other arguments are bound as variables
+type WindowFunction struct {
+	Name string
+	Args []any
+}
+
+// Build build window function
+func (fn WindowFunction) Build(builder clause.Builder) {
+	builder.WriteString(strings.ToUpper(fn.Name))
+	builder.WriteByte('(')
+	for idx, arg := range fn.Args {
+		if idx > 0 {
+			builder.WriteString(", ")
+		}
+		switch value := arg.(type) {
+		case string, clause.Column:
+			builder.WriteQuoted(value)
+		case int:
+			builder.WriteString(fmt.Sprint(value))
+		case clause.Expression:
+			value.Build(builder)
+		default:
+			builder.AddVar(builder, value)
+		}
+	}
+	builder.WriteByte(')')
+}
+
+// RowNumber ROW_NUMBER()
+func RowNumber() WindowFunction {
+	return WindowFunction{Name: "ROW_NUMBER"}
+}
+
+// Rank RANK()
+func Rank() WindowFunction {
+	return WindowFunction{Name: "RANK"}
+}
+
+// DenseRank DENSE_RANK()
+func DenseRank() WindowFunction {
+	return WindowFunction{Name: "DENSE_RANK"}
+}
+
+// Lag LAG(column, offset, default)
+//
+//	Lag("price") // LAG(`price`)
+//	Lag("price", 2, 0) // LAG(`price`, 2, 0)
+func Lag(column string, args ...any) WindowFunction {
+	return WindowFunction{Name: "LAG", Args: append([]any{column}, args...)}
+}
+
+// Lead LEAD(column, offset, default)
+func Lead(column string, args ...any) WindowFunction {
+	return WindowFunction{Name: "LEAD", Args: append([]any{column}, args...)}
+}
+
+// FirstValue FIRST_VALUE(column)
+func FirstValue(column string) WindowFunction {
+	return WindowFunction{Name: "FIRST_VALUE", Args: []any{column}}
+}
+
+// LastValue LAST_VALUE(column)
+func LastValue(column string) WindowFunction {
+	return WindowFunction{Name: "LAST_VALUE", Args: []any{column}}
+}
+
+// Sum SUM(column)
+func Sum(column string) WindowFunction {
+	return WindowFunction{Name: "SUM", Args: []any{column}}
+}
+
+// Avg AVG(column)
+func Avg(column string) WindowFunction {
+	return WindowFunction{Name: "AVG", Args: []any{column}}
+}
+
+// Count COUNT(column), COUNT(*) if column is empty
+func Count(column string) WindowFunction {
+	if column == "" || column == "*" {
+		return WindowFunction{Name: "COUNT", Args: []any{clause.Expr{SQL: "*"}}}
+	}
+	return WindowFunction{Name: "COUNT", Args: []any{column}}
+}
+
+// Window window function expression
+//
+//	Window{
+//		Function:    RowNumber(),
+//		PartitionBy: []clause.Column{{Name: "department_id"}},
+//		OrderBy:     []clause.OrderByColumn{{Column: clause.Column{Name: "salary"}, Desc: true}},
+//		Alias:       "rank",
+//	}
+//	// ROW_NUMBER() OVER (PARTITION BY `department_id` ORDER BY `salary` DESC) AS `rank`
+type Window struct {
+	Function    clause.Expression
+	PartitionBy []clause.Column
+	OrderBy     []clause.OrderByColumn
+	Frame       *Frame
+	Alias       string
+}
+
+// Build build window function expression
+func (window Window) Build(builder clause.Builder) {
+	window.Function.Build(builder)
+	builder.WriteString(" OVER (")
+	written := false
+	if len(window.PartitionBy) > 0 {
+		builder.WriteString("PARTITION BY ")
+		for idx, column := range window.PartitionBy {
+			if idx > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteQuoted(column)
+		}
+		written = true
+	}
+	if len(window.OrderBy) > 0 {
+		if written {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString("ORDER BY ")
+		clause.OrderBy{Columns: window.OrderBy}.Build(builder)
+		written = true
+	}
+	if window.Frame != nil {
+		if written {
+			builder.WriteByte(' ')
+		}
+		window.Frame.Build(builder)
+	}
+	builder.WriteByte(')')
+	if window.Alias != "" {
+		builder.WriteString(" AS ")
+		builder.WriteQuoted(window.Alias)
+	}
+}
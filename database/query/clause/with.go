@@ -0,0 +1,64 @@
+package clause
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CTE common table expression
+//
+// if Recursive is not nil, the CTE is recursive and Query is the anchor query combined with Recursive by UNION ALL
+type CTE struct {
+	Name      string
+	Columns   []string
+	Query     *gorm.DB
+	Recursive *gorm.DB
+}
+
+// Build build common table expression
+func (cte CTE) Build(builder clause.Builder) {
+	builder.WriteQuoted(cte.Name)
+	if len(cte.Columns) > 0 {
+		builder.WriteString(" (")
+		for idx, column := range cte.Columns {
+			if idx > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteQuoted(column)
+		}
+		builder.WriteByte(')')
+	}
+	builder.WriteString(" AS (")
+	builder.AddVar(builder, cte.Query)
+	if cte.Recursive != nil {
+		builder.WriteByte(' ')
+		SetOperation{Operator: UnionAll, Query: cte.Recursive}.Build(builder)
+	}
+	builder.WriteByte(')')
+}
+
+// With with clause, it's built before the SELECT clause
+//
+//	c := stmt.Clauses["SELECT"]
+//	c.BeforeExpression = With{CTEs: ctes}
+//	stmt.Clauses["SELECT"] = c
+type With struct {
+	CTEs []CTE
+}
+
+// Build build with clause
+func (with With) Build(builder clause.Builder) {
+	builder.WriteString("WITH ")
+	for _, cte := range with.CTEs {
+		if cte.Recursive != nil {
+			builder.WriteString("RECURSIVE ")
+			break
+		}
+	}
+	for idx, cte := range with.CTEs {
+		if idx > 0 {
+			builder.WriteString(", ")
+		}
+		cte.Build(builder)
+	}
+}
@@ -101,21 +101,15 @@ func Find[T any](db *gorm.DB, conditions ...any) *list.ArrayList[T] {
 	}
 }
 
-func Chunk[T any](db gorm.DB, batchSize int, callback func(tx *gorm.DB, batch int) error) *list.ArrayList[T] {
-	var allModels = []T{}
+// Chunk finds all matched records in batches of batchSize, the records of each batch are passed to callback
+// and released before the next batch is queried
+//
+// errors of the query or returned by callback stop the iteration and are returned
+func Chunk[T any](db *gorm.DB, batchSize int, callback func(tx *gorm.DB, models []T, batch int) error) error {
 	var models = []T{}
-	if err := db.FindInBatches(&models, batchSize, func(tx *gorm.DB, batch int) error {
-		if err := callback(tx, batch); err != nil {
-			return err
-		} else {
-			allModels = append(allModels, models...)
-			return nil
-		}
-	}).Error; err != nil {
-		panic(err)
-	} else {
-		return list.NewArrayList[T](allModels...)
-	}
+	return db.FindInBatches(&models, batchSize, func(tx *gorm.DB, batch int) error {
+		return callback(tx, models, batch)
+	}).Error
 }
 
 func Count(db *gorm.DB) int64 {
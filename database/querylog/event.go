@@ -0,0 +1,37 @@
+package querylog
+
+import (
+	"time"
+
+	"github.com/wardonne/gopi/eventbus"
+)
+
+// Topic the topic of [QueryEvent]
+const Topic = "database.query"
+
+var _ eventbus.EventInterface = (*QueryEvent)(nil)
+
+// QueryEvent is dispatched after every executed query
+type QueryEvent struct {
+	// SQL the executed sql with placeholders
+	SQL string
+	// Bindings the bound values of SQL
+	Bindings []any
+	// Duration the elapsed time of the query
+	Duration time.Duration
+	// RowsAffected the rows affected or returned by the query
+	RowsAffected int64
+	// Caller the file and line which executes the query
+	Caller string
+	// Connection the alias of the connection
+	Connection string
+	// Error the error of the query
+	Error error
+	// Time the time when the query started
+	Time time.Time
+}
+
+// Topic implements [eventbus.EventInterface].Topic
+func (event *QueryEvent) Topic() string {
+	return Topic
+}
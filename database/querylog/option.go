@@ -0,0 +1,46 @@
+package querylog
+
+import (
+	"time"
+
+	"github.com/wardonne/gopi/eventbus"
+	"github.com/wardonne/gopi/logger"
+)
+
+// Option query log option
+type Option func(queryLog *QueryLog)
+
+// WithEventBus publishes every [QueryEvent] on bus
+func WithEventBus(bus eventbus.IEventBus) Option {
+	return func(queryLog *QueryLog) {
+		queryLog.bus = bus
+	}
+}
+
+// WithLogger sets the logger used to warn slow queries
+func WithLogger(logger *logger.Logger) Option {
+	return func(queryLog *QueryLog) {
+		queryLog.logger = logger
+	}
+}
+
+// WithSlowThreshold warns queries which take longer than threshold, zero disables the warning
+func WithSlowThreshold(threshold time.Duration) Option {
+	return func(queryLog *QueryLog) {
+		queryLog.slowThreshold = threshold
+	}
+}
+
+// WithConnection sets the connection alias reported by [QueryEvent]
+func WithConnection(alias string) Option {
+	return func(queryLog *QueryLog) {
+		queryLog.connection = alias
+	}
+}
+
+// WithHandler adds a handler called with every [QueryEvent]
+func WithHandler(handler func(event *QueryEvent)) Option {
+	return func(queryLog *QueryLog) {
+		queryLog.handlers = append(queryLog.handlers, handler)
+	}
+}
@@ -0,0 +1,170 @@
+package querylog
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wardonne/gopi/eventbus"
+	"github.com/wardonne/gopi/logger"
+	"gorm.io/gorm"
+)
+
+// DefaultConnection default connection alias
+const DefaultConnection = "default"
+
+var _ gorm.Plugin = (*QueryLog)(nil)
+
+// QueryLog records executed queries
+//
+// Every query is reported as a [QueryEvent] to the handlers and the event bus,
+// queries slower than the threshold are warned through the logger.
+//
+//	bus := eventbus.NewEventBus()
+//	queryLog := querylog.New(
+//		querylog.WithEventBus(bus),
+//		querylog.WithLogger(log),
+//		querylog.WithSlowThreshold(200*time.Millisecond),
+//	)
+//	if err := db.Use(queryLog); err != nil {
+//		panic(err)
+//	}
+//	bus.OnTopic(querylog.Topic, []eventbus.ListenerClause{
+//		func(event eventbus.EventInterface) bool {
+//			fmt.Println(event.(*querylog.QueryEvent).SQL)
+//			return true
+//		},
+//	})
+type QueryLog struct {
+	bus           eventbus.IEventBus
+	logger        *logger.Logger
+	slowThreshold time.Duration
+	connection    string
+	handlers      []func(event *QueryEvent)
+}
+
+// New creates a new [QueryLog] instance
+func New(options ...Option) *QueryLog {
+	queryLog := &QueryLog{
+		connection: DefaultConnection,
+		handlers:   make([]func(event *QueryEvent), 0),
+	}
+	for _, option := range options {
+		option(queryLog)
+	}
+	return queryLog
+}
+
+// Name implements [gorm.Plugin].Name
+func (queryLog *QueryLog) Name() string {
+	return "gopi:querylog"
+}
+
+// Initialize implements [gorm.Plugin].Initialize, it registers the query event to the event bus
+func (queryLog *QueryLog) Initialize(db *gorm.DB) error {
+	if queryLog.bus != nil {
+		registered := false
+		for _, topic := range queryLog.bus.ListTopics() {
+			if topic == Topic {
+				registered = true
+				break
+			}
+		}
+		if !registered {
+			if err := queryLog.bus.AddEvent(new(QueryEvent)); err != nil {
+				return err
+			}
+		}
+	}
+	return register(db, queryLog.Name(), queryLog.handle)
+}
+
+func (queryLog *QueryLog) handle(event *QueryEvent) {
+	event.Connection = queryLog.connection
+	for _, handler := range queryLog.handlers {
+		handler(event)
+	}
+	if queryLog.bus != nil {
+		_ = queryLog.bus.Dispatch(event, nil)
+	}
+	if queryLog.logger != nil && queryLog.slowThreshold > 0 && event.Duration >= queryLog.slowThreshold {
+		queryLog.logger.Warn("slow query", map[string]any{
+			"sql":           event.SQL,
+			"bindings":      event.Bindings,
+			"duration":      event.Duration.String(),
+			"rows_affected": event.RowsAffected,
+			"caller":        event.Caller,
+			"connection":    event.Connection,
+		})
+	}
+}
+
+// register registers callbacks around every gorm processor, handler is called after the query finished
+func register(db *gorm.DB, name string, handler func(event *QueryEvent)) error {
+	startKey := name + ":start"
+	before := func(db *gorm.DB) {
+		// statements built in dry run mode, such as subqueries and ToSQL, are not executed
+		if db.DryRun {
+			return
+		}
+		db.InstanceSet(startKey, time.Now())
+	}
+	after := func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		start := value.(time.Time)
+		vars := make([]any, len(db.Statement.Vars))
+		copy(vars, db.Statement.Vars)
+		handler(&QueryEvent{
+			SQL:          db.Statement.SQL.String(),
+			Bindings:     vars,
+			Duration:     time.Since(start),
+			RowsAffected: db.Statement.RowsAffected,
+			Caller:       caller(),
+			Error:        db.Error,
+			Time:         start,
+		})
+	}
+	callback := db.Callback()
+	registers := []func() error{
+		func() error { return callback.Create().Before("*").Register(name+":before_create", before) },
+		func() error { return callback.Create().After("*").Register(name+":after_create", after) },
+		func() error { return callback.Query().Before("*").Register(name+":before_query", before) },
+		func() error { return callback.Query().After("*").Register(name+":after_query", after) },
+		func() error { return callback.Update().Before("*").Register(name+":before_update", before) },
+		func() error { return callback.Update().After("*").Register(name+":after_update", after) },
+		func() error { return callback.Delete().Before("*").Register(name+":before_delete", before) },
+		func() error { return callback.Delete().After("*").Register(name+":after_delete", after) },
+		func() error { return callback.Row().Before("*").Register(name+":before_row", before) },
+		func() error { return callback.Row().After("*").Register(name+":after_row", after) },
+		func() error { return callback.Raw().Before("*").Register(name+":before_raw", before) },
+		func() error { return callback.Raw().After("*").Register(name+":after_raw", after) },
+	}
+	for _, register := range registers {
+		if err := register(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// caller returns the first caller outside gorm and the database packages
+func caller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if strings.HasSuffix(frame.File, "_test.go") ||
+			(!strings.HasPrefix(frame.Function, "gorm.io/") &&
+				!strings.HasPrefix(frame.Function, "github.com/wardonne/gopi/database/") &&
+				!strings.HasPrefix(frame.Function, "runtime.")) {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
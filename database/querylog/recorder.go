@@ -0,0 +1,123 @@
+package querylog
+
+import (
+	"sort"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+var _ gorm.Plugin = (*Recorder)(nil)
+
+// TestingT is the subset of [testing.T] used by [Recorder]
+type TestingT interface {
+	Errorf(format string, args ...any)
+}
+
+// Recorder captures executed queries, it is designed for tests
+//
+//	recorder, err := querylog.Capture(db)
+//	// run the code under test
+//	assert.Equal(t, 2, recorder.Count())
+//	assert.Empty(t, recorder.NPlusOne(3))
+type Recorder struct {
+	mu      *sync.Mutex
+	queries []*QueryEvent
+}
+
+// NewRecorder creates a new [Recorder] instance
+func NewRecorder() *Recorder {
+	return &Recorder{
+		mu:      new(sync.Mutex),
+		queries: make([]*QueryEvent, 0),
+	}
+}
+
+// Capture creates a new [Recorder] and registers it to db
+func Capture(db *gorm.DB) (*Recorder, error) {
+	recorder := NewRecorder()
+	if err := db.Use(recorder); err != nil {
+		return nil, err
+	}
+	return recorder, nil
+}
+
+// Name implements [gorm.Plugin].Name
+func (recorder *Recorder) Name() string {
+	return "gopi:querylog:recorder"
+}
+
+// Initialize implements [gorm.Plugin].Initialize
+func (recorder *Recorder) Initialize(db *gorm.DB) error {
+	return register(db, recorder.Name(), recorder.Record)
+}
+
+// Record records a query event
+func (recorder *Recorder) Record(event *QueryEvent) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.queries = append(recorder.queries, event)
+}
+
+// Queries returns the recorded queries in execution order
+func (recorder *Recorder) Queries() []*QueryEvent {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	queries := make([]*QueryEvent, len(recorder.queries))
+	copy(queries, recorder.queries)
+	return queries
+}
+
+// SQL returns the sql of the recorded queries in execution order
+func (recorder *Recorder) SQL() []string {
+	queries := recorder.Queries()
+	sqls := make([]string, 0, len(queries))
+	for _, query := range queries {
+		sqls = append(sqls, query.SQL)
+	}
+	return sqls
+}
+
+// Count returns the number of the recorded queries
+func (recorder *Recorder) Count() int {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return len(recorder.queries)
+}
+
+// Reset clears the recorded queries
+func (recorder *Recorder) Reset() {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.queries = make([]*QueryEvent, 0)
+}
+
+// NPlusOne returns the sql executed at least threshold times with their counts,
+// repeated identical statements usually mean an N+1 query pattern
+func (recorder *Recorder) NPlusOne(threshold int) map[string]int {
+	counts := make(map[string]int)
+	for _, query := range recorder.Queries() {
+		counts[query.SQL]++
+	}
+	repeated := make(map[string]int)
+	for sql, count := range counts {
+		if count >= threshold {
+			repeated[sql] = count
+		}
+	}
+	return repeated
+}
+
+// AssertNoNPlusOne reports an error to t for every sql executed at least threshold times
+func (recorder *Recorder) AssertNoNPlusOne(t TestingT, threshold int) bool {
+	repeated := recorder.NPlusOne(threshold)
+	sqls := make([]string, 0, len(repeated))
+	for sql := range repeated {
+		sqls = append(sqls, sql)
+	}
+	sort.Strings(sqls)
+	for _, sql := range sqls {
+		t.Errorf("possible N+1 query, executed %d times: %s", repeated[sql], sql)
+	}
+	return len(sqls) == 0
+}
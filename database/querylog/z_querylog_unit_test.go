@@ -0,0 +1,152 @@
+package querylog
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/database/query/builder"
+	"github.com/wardonne/gopi/eventbus"
+	"github.com/wardonne/gopi/logger"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+type user struct {
+	ID   uint
+	Name string
+}
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.Nil(t, err)
+	mockDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	assert.Nil(t, err)
+	return mockDB, mock
+}
+
+func TestQueryLog(t *testing.T) {
+	db, mock := newMockDB(t)
+	bus := eventbus.NewEventBus()
+	log := logger.Must(logger.New())
+	output := new(bytes.Buffer)
+	log.Out = output
+	events := make([]*QueryEvent, 0)
+	assert.Nil(t, db.Use(New(
+		WithEventBus(bus),
+		WithLogger(log),
+		WithSlowThreshold(50*time.Millisecond),
+		WithConnection("main"),
+	)))
+	assert.Nil(t, bus.OnTopic(Topic, []eventbus.ListenerClause{
+		func(event eventbus.EventInterface) bool {
+			events = append(events, event.(*QueryEvent))
+			return true
+		},
+	}))
+
+	t.Run("query event", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `name` = ?").WithArgs("user1").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "user1").AddRow(2, "user1"))
+		var dest = make([]user, 0)
+		err := builder.NewBuilder(db).Table("users").Where("name", "user1").Find(&dest)
+		assert.Nil(t, err)
+		assert.Len(t, events, 1)
+		event := events[0]
+		events = events[:0]
+		assert.Equal(t, "SELECT * FROM `users` WHERE `name` = ?", strings.Join(strings.Fields(event.SQL), " "))
+		assert.Equal(t, []any{"user1"}, event.Bindings)
+		assert.Equal(t, int64(2), event.RowsAffected)
+		assert.Equal(t, "main", event.Connection)
+		assert.Contains(t, event.Caller, "z_querylog_unit_test.go")
+		assert.Nil(t, event.Error)
+		assert.Empty(t, output.String())
+	})
+
+	t.Run("subquery and dry run are skipped", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `id` IN (SELECT `user_id` FROM `orders` )").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+		var dest = make([]user, 0)
+		err := builder.NewBuilder(db).Table("users").WhereIn("id", builder.NewBuilder(db).Table("orders").Select("user_id")).Find(&dest)
+		assert.Nil(t, err)
+		assert.Len(t, events, 1)
+		events = events[:0]
+		_ = builder.NewBuilder(db).Table("users").ToSQL()
+		assert.Len(t, events, 0)
+	})
+
+	t.Run("exec event", func(t *testing.T) {
+		mock.ExpectExec("UPDATE `users` SET `name`=? WHERE `id` = ?").WithArgs("user2", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+		err := builder.NewBuilder(db).Table("users").Where("id", 1).Update(map[string]any{"name": "user2"})
+		assert.Nil(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, int64(1), events[0].RowsAffected)
+	})
+
+	t.Run("error event", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users`").WillReturnError(fmt.Errorf("broken"))
+		var dest = make([]user, 0)
+		err := builder.NewBuilder(db).Table("users").Find(&dest)
+		assert.NotNil(t, err)
+		assert.Len(t, events, 2)
+		assert.EqualError(t, events[1].Error, "broken")
+	})
+
+	t.Run("slow query", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users`").WillDelayFor(60 * time.Millisecond).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+		var dest = make([]user, 0)
+		err := builder.NewBuilder(db).Table("users").Find(&dest)
+		assert.Nil(t, err)
+		assert.Len(t, events, 3)
+		assert.GreaterOrEqual(t, events[2].Duration, 50*time.Millisecond)
+		assert.Contains(t, output.String(), "slow query")
+		assert.Contains(t, output.String(), "connection=main")
+	})
+}
+
+type errorRecorder struct {
+	messages []string
+}
+
+func (r *errorRecorder) Errorf(format string, args ...any) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+func TestRecorder(t *testing.T) {
+	db, mock := newMockDB(t)
+	recorder, err := Capture(db)
+	assert.Nil(t, err)
+
+	for i := 1; i <= 3; i++ {
+		mock.ExpectQuery("SELECT * FROM `users` WHERE `id` = ?").WithArgs(i).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(i, "user"))
+		var dest = make([]user, 0)
+		assert.Nil(t, builder.NewBuilder(db).Table("users").Where("id", i).Find(&dest))
+	}
+	mock.ExpectQuery("SELECT count(*) FROM `users`").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	_, err = builder.NewBuilder(db).Table("users").Count()
+	assert.Nil(t, err)
+
+	assert.Equal(t, 4, recorder.Count())
+	assert.Len(t, recorder.SQL(), 4)
+	repeated := recorder.NPlusOne(3)
+	assert.Len(t, repeated, 1)
+	for _, count := range repeated {
+		assert.Equal(t, 3, count)
+	}
+	assert.Empty(t, recorder.NPlusOne(4))
+
+	reporter := new(errorRecorder)
+	assert.False(t, recorder.AssertNoNPlusOne(reporter, 3))
+	assert.Len(t, reporter.messages, 1)
+	assert.Contains(t, reporter.messages[0], "executed 3 times")
+
+	recorder.Reset()
+	assert.Equal(t, 0, recorder.Count())
+	assert.True(t, recorder.AssertNoNPlusOne(reporter, 1))
+}
@@ -0,0 +1,37 @@
+package tenancy
+
+import "context"
+
+type tenantKey struct{}
+
+type bypassKey struct{}
+
+// WithTenant returns a copy of ctx which carries the tenant
+func WithTenant(ctx context.Context, tenant any) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// FromContext returns the tenant carried by ctx
+//
+// if ctx doesn't carry a tenant, the second return value will be false
+func FromContext(ctx context.Context) (any, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	tenant := ctx.Value(tenantKey{})
+	return tenant, tenant != nil
+}
+
+// Bypass returns a copy of ctx in which queries are not scoped by tenant
+func Bypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey{}, true)
+}
+
+// IsBypassed returns whether the tenant isolation is bypassed in ctx
+func IsBypassed(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	bypassed, _ := ctx.Value(bypassKey{}).(bool)
+	return bypassed
+}
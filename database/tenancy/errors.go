@@ -0,0 +1,9 @@
+package tenancy
+
+import "errors"
+
+// tenancy errors
+var (
+	ErrTenantNotFound   = errors.New("tenant is not found in context, use tenancy.Bypass to run unscoped queries")
+	ErrConnectionAbsent = errors.New("database connection of tenant is not registered")
+)
@@ -0,0 +1,42 @@
+package tenancy
+
+import "fmt"
+
+// Option tenancy option
+type Option func(tenancy *Tenancy)
+
+// WithColumn sets the tenant column, default is [DefaultColumn]
+func WithColumn(column string) Option {
+	return func(tenancy *Tenancy) {
+		tenancy.column = column
+	}
+}
+
+// WithTables sets the tables isolated by tenant
+//
+// tables can be table names or model instances
+func WithTables(tables ...any) Option {
+	return func(tenancy *Tenancy) {
+		tenancy.tables = append(tenancy.tables, tables...)
+	}
+}
+
+// WithSchemaMode switches to schema-per-tenant mode
+//
+// In schema mode, queries are not filtered by the tenant column,
+// but run on the database connection resolved by resolver, see [Tenancy.DB]
+func WithSchemaMode(resolver func(tenant any) string) Option {
+	return func(tenancy *Tenancy) {
+		tenancy.mode = ModeSchema
+		tenancy.aliasResolver = resolver
+	}
+}
+
+// AliasFormat returns an alias resolver which formats the tenant with format
+//
+//	tenancy.New(tenancy.WithSchemaMode(tenancy.AliasFormat("tenant_%v")))
+func AliasFormat(format string) func(tenant any) string {
+	return func(tenant any) string {
+		return fmt.Sprintf(format, tenant)
+	}
+}
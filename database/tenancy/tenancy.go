@@ -0,0 +1,165 @@
+package tenancy
+
+import (
+	"context"
+
+	"github.com/wardonne/gopi/database"
+	"github.com/wardonne/gopi/database/query/builder"
+	"github.com/wardonne/gopi/support/maps"
+	"gorm.io/gorm"
+)
+
+// Mode tenancy mode
+type Mode int
+
+// Tenancy modes
+const (
+	// ModeColumn all tenants share the same schema and rows are isolated by the tenant column
+	ModeColumn Mode = iota + 1
+	// ModeSchema every tenant has its own schema and connection
+	ModeSchema
+)
+
+// ScopeName the name of the global scope registered for isolated tables
+const ScopeName = "tenant"
+
+// DefaultColumn default tenant column
+const DefaultColumn = "tenant_id"
+
+var _ gorm.Plugin = (*Tenancy)(nil)
+
+// Tenancy multi-tenant query isolation
+//
+// In column mode, every query of the isolated tables built by [builder.Builder] is filtered by
+// `tenant_id = ?` and the tenant column is filled on insert. The tenant is read from the context
+// bound by [builder.Builder.WithContext], queries without a tenant are refused unless [Bypass] is used.
+//
+//	t := tenancy.New(tenancy.WithTables(new(User), "orders"))
+//	t.Register()
+//	if err := db.Use(t); err != nil {
+//		panic(err)
+//	}
+//	ctx := tenancy.WithTenant(context.Background(), 1)
+//	builder.NewBuilder(db).WithContext(ctx).Table("orders").Find(&orders)
+type Tenancy struct {
+	mode          Mode
+	column        string
+	tables        []any
+	isolated      *maps.SyncHashMap[string, bool]
+	aliasResolver func(tenant any) string
+}
+
+// New creates a new [Tenancy] instance
+func New(options ...Option) *Tenancy {
+	tenancy := &Tenancy{
+		mode:     ModeColumn,
+		column:   DefaultColumn,
+		tables:   make([]any, 0),
+		isolated: maps.NewSyncHashMap[string, bool](),
+	}
+	for _, option := range options {
+		option(tenancy)
+	}
+	for _, table := range tenancy.tables {
+		tenancy.isolated.Set(builder.TableName(table), true)
+	}
+	return tenancy
+}
+
+// Mode returns the tenancy mode
+func (tenancy *Tenancy) Mode() Mode {
+	return tenancy.mode
+}
+
+// Column returns the tenant column
+func (tenancy *Tenancy) Column() string {
+	return tenancy.column
+}
+
+// IsIsolated returns whether the table is isolated by tenant
+func (tenancy *Tenancy) IsIsolated(table string) bool {
+	return tenancy.isolated.ContainsKey(table)
+}
+
+// Register registers the tenant scope to all isolated tables
+func (tenancy *Tenancy) Register() {
+	for _, table := range tenancy.isolated.Keys() {
+		builder.RegisterGlobalScope(table, ScopeName, tenancy.scope)
+	}
+}
+
+// Unregister removes the tenant scope from all isolated tables
+func (tenancy *Tenancy) Unregister() {
+	for _, table := range tenancy.isolated.Keys() {
+		builder.RemoveGlobalScope(table, ScopeName)
+	}
+}
+
+// Name implements [gorm.Plugin].Name
+func (tenancy *Tenancy) Name() string {
+	return "gopi:tenancy"
+}
+
+// Initialize implements [gorm.Plugin].Initialize, it fills the tenant column before creating
+func (tenancy *Tenancy) Initialize(db *gorm.DB) error {
+	return db.Callback().Create().Before("gorm:create").Register("gopi:tenancy:create", tenancy.beforeCreate)
+}
+
+// DB returns the database connection of the tenant carried by ctx
+//
+// In column mode, it always returns the default connection.
+// In schema mode, it returns the connection registered with the alias resolved from the tenant.
+func (tenancy *Tenancy) DB(ctx context.Context) (*gorm.DB, error) {
+	if tenancy.mode != ModeSchema {
+		return database.DB().WithContext(ctx), nil
+	}
+	tenant, ok := FromContext(ctx)
+	if !ok {
+		return nil, ErrTenantNotFound
+	}
+	alias := tenancy.aliasResolver(tenant)
+	if !database.Has(alias) {
+		return nil, ErrConnectionAbsent
+	}
+	return database.DB(alias).WithContext(ctx), nil
+}
+
+// Builder returns a [builder.Builder] of the tenant carried by ctx, see [Tenancy.DB]
+func (tenancy *Tenancy) Builder(ctx context.Context) (*builder.Builder, error) {
+	db, err := tenancy.DB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return builder.NewBuilder(db), nil
+}
+
+func (tenancy *Tenancy) scope(query *builder.Builder) *builder.Builder {
+	if tenancy.mode != ModeColumn {
+		return query
+	}
+	ctx := query.Context()
+	if IsBypassed(ctx) {
+		return query
+	}
+	tenant, ok := FromContext(ctx)
+	if !ok {
+		return query.AddError(ErrTenantNotFound)
+	}
+	return query.Where(query.QualifyColumn(tenancy.column), tenant)
+}
+
+func (tenancy *Tenancy) beforeCreate(db *gorm.DB) {
+	if tenancy.mode != ModeColumn || db.Error != nil || !tenancy.IsIsolated(db.Statement.Table) {
+		return
+	}
+	ctx := db.Statement.Context
+	if IsBypassed(ctx) {
+		return
+	}
+	tenant, ok := FromContext(ctx)
+	if !ok {
+		_ = db.AddError(ErrTenantNotFound)
+		return
+	}
+	db.Statement.SetColumn(tenancy.column, tenant, true)
+}
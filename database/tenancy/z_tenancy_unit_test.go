@@ -0,0 +1,100 @@
+package tenancy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/database/query/builder"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type order struct {
+	ID       uint
+	TenantID string
+	Amount   int
+}
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.Nil(t, err)
+	mockDB, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	assert.Nil(t, err)
+	return mockDB, mock
+}
+
+func TestTenancy(t *testing.T) {
+	db, mock := newMockDB(t)
+	tenancy := New(WithTables(new(order)))
+	tenancy.Register()
+	defer tenancy.Unregister()
+	assert.Nil(t, db.Use(tenancy))
+
+	ctx := WithTenant(context.Background(), "acme")
+
+	t.Run("query scoped by tenant", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `orders` WHERE `amount` > ? AND `orders`.`tenant_id` = ?").WithArgs(10, "acme").WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "amount"}).AddRow(1, "acme", 20))
+		var dest = make([]order, 0)
+		err := builder.NewBuilder(db).WithContext(ctx).Table("orders").WhereGt("amount", 10).Find(&dest)
+		assert.Nil(t, err)
+		assert.Len(t, dest, 1)
+	})
+
+	t.Run("query guessed from model", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `orders` WHERE `orders`.`tenant_id` = ?").WithArgs("acme").WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "amount"}).AddRow(1, "acme", 20))
+		var dest = make([]order, 0)
+		err := builder.NewBuilder(db).WithContext(ctx).Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("query without tenant", func(t *testing.T) {
+		var dest = make([]order, 0)
+		err := builder.NewBuilder(db).Table("orders").Find(&dest)
+		assert.ErrorIs(t, err, ErrTenantNotFound)
+	})
+
+	t.Run("query bypassed", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `orders`").WithoutArgs().WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "amount"}).AddRow(1, "acme", 20))
+		var dest = make([]order, 0)
+		err := builder.NewBuilder(db).WithContext(Bypass(context.Background())).Table("orders").Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("query of not isolated table", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM `users`").WithoutArgs().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		var dest = make([]map[string]any, 0)
+		err := builder.NewBuilder(db).Table("users").Find(&dest)
+		assert.Nil(t, err)
+	})
+
+	t.Run("insert fills tenant", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO `orders` (`tenant_id`,`amount`) VALUES (?,?)").WithArgs("acme", 30).WillReturnResult(sqlmock.NewResult(2, 1))
+		value := &order{Amount: 30}
+		err := builder.NewBuilder(db).WithContext(ctx).Create(value)
+		assert.Nil(t, err)
+		assert.Equal(t, "acme", value.TenantID)
+	})
+
+	t.Run("insert without tenant", func(t *testing.T) {
+		err := builder.NewBuilder(db).Create(&order{Amount: 30})
+		assert.ErrorIs(t, err, ErrTenantNotFound)
+	})
+
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+	tenant, ok := FromContext(WithTenant(context.Background(), 1))
+	assert.True(t, ok)
+	assert.Equal(t, 1, tenant)
+	assert.False(t, IsBypassed(context.Background()))
+	assert.True(t, IsBypassed(Bypass(context.Background())))
+}
@@ -0,0 +1,31 @@
+package logger
+
+import "github.com/wardonne/gopi/container"
+
+// Provider registers the [Logger] into the container and sets it as the default logger on booting
+type Provider struct {
+	opts []Option
+}
+
+// NewProvider creates a new [Provider] instance, the logger is created with opts
+func NewProvider(opts ...Option) *Provider {
+	return &Provider{opts: opts}
+}
+
+// Register registers the [Logger] as a singleton
+func (p *Provider) Register(c *container.Container) error {
+	container.Singleton[*Logger](c, func() (*Logger, error) {
+		return New(p.opts...)
+	})
+	return nil
+}
+
+// Boot sets the [Logger] as the default logger
+func (p *Provider) Boot(c *container.Container) error {
+	instance, err := container.Resolve[*Logger](c)
+	if err != nil {
+		return err
+	}
+	SetDefault(instance)
+	return nil
+}
@@ -0,0 +1,92 @@
+package context
+
+import (
+	libctx "context"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/wardonne/gopi/support/maps"
+)
+
+// ContextKey the key type of request values in [Request.Context]
+//
+//	request.Set("user", user)
+//	request.Context().Value(context.ContextKey("user")) // user
+type ContextKey string
+
+// valuesKey the key of the values map in [Request.Context]
+type valuesKey struct{}
+
+// valuesContext exposes the values of the request through [libctx.Context]
+//
+// values are looked up by [ContextKey] or string keys, other keys are looked up in the parent context.
+type valuesContext struct {
+	libctx.Context
+	mu     *sync.RWMutex
+	values *maps.HashMap[string, any]
+}
+
+// Value implements [libctx.Context]
+func (ctx *valuesContext) Value(key any) any {
+	var name string
+	switch k := key.(type) {
+	case valuesKey:
+		return ctx.values
+	case ContextKey:
+		name = string(k)
+	case string:
+		name = k
+	default:
+		return ctx.Context.Value(key)
+	}
+	ctx.mu.RLock()
+	defer ctx.mu.RUnlock()
+	if ctx.values.ContainsKey(name) {
+		return ctx.values.Get(name)
+	}
+	return ctx.Context.Value(key)
+}
+
+// withValues binds the request values to ctx if they are not bound yet
+func (request *Request) withValues(ctx libctx.Context) libctx.Context {
+	if values, ok := ctx.Value(valuesKey{}).(*maps.HashMap[string, any]); ok && values == request.Values {
+		return ctx
+	}
+	return &valuesContext{Context: ctx, mu: request.mu, values: request.Values}
+}
+
+// Context returns the context of the request
+//
+// it's canceled when the client disconnects or the server shuts down, and the values set by [Request.Set]
+// are visible by [ContextKey] or string keys, so it can be passed to database queries and outbound requests.
+//
+//	builder.NewBuilder(db).WithContext(request.Context()).Find(&users)
+func (request *Request) Context() libctx.Context {
+	return request.Request.Context()
+}
+
+// WithContext returns a shallow copy of the request with its context changed to ctx,
+// values of the request are shared with the copy
+//
+//	ctx, cancel := context.WithTimeout(request.Context(), time.Second)
+//	defer cancel()
+//	return next(request.WithContext(ctx))
+func (request *Request) WithContext(ctx libctx.Context) *Request {
+	if ctx == nil {
+		panic("nil context")
+	}
+	clone := request.Clone()
+	clone.form = request.form
+	clone.Request = request.Request.WithContext(request.withValues(ctx))
+	return clone
+}
+
+// NewOutgoingRequest creates an outbound http request bound to the context of the request,
+// so that it's canceled with the request
+//
+//	req, err := request.NewOutgoingRequest(http.MethodGet, "https://example.com", nil)
+//	resp, err := http.DefaultClient.Do(req)
+func (request *Request) NewOutgoingRequest(method, url string, body io.Reader) (*http.Request, error) {
+	return http.NewRequestWithContext(request.Context(), method, url, body)
+}
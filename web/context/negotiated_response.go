@@ -0,0 +1,68 @@
+package context
+
+import "net/http"
+
+// NegotiatedResponse used to send data in the format chosen by the Accept header, see [Negotiate]
+type NegotiatedResponse struct {
+	*Response
+	data    any
+	offers  []string
+	lenient bool
+}
+
+// SetContent sets response body content
+func (negotiatedResponse *NegotiatedResponse) SetContent(data any) {
+	negotiatedResponse.data = data
+}
+
+// Offer restricts the content types to choose from, all registered content types are offered by default
+//
+//	response.Negotiate(rows).Offer("text/csv", context.MIMEJSON)
+func (negotiatedResponse *NegotiatedResponse) Offer(contentTypes ...string) *NegotiatedResponse {
+	negotiatedResponse.offers = contentTypes
+	return negotiatedResponse
+}
+
+// Lenient sends the data in the default content type instead of [http.StatusNotAcceptable]
+// when none of the offers is acceptable, e.g. for error responses
+func (negotiatedResponse *NegotiatedResponse) Lenient() *NegotiatedResponse {
+	negotiatedResponse.lenient = true
+	return negotiatedResponse
+}
+
+// Send sends the response, [http.StatusNotAcceptable] is sent if none of the offers is acceptable
+//
+// the data is sent in the default content type if the chosen renderer fails to encode it.
+func (negotiatedResponse *NegotiatedResponse) Send(w http.ResponseWriter, r *http.Request) {
+	offers := negotiatedResponse.offers
+	if len(offers) == 0 {
+		offers = ContentTypes()
+	}
+	negotiatedResponse.SetHeader("Vary", "Accept")
+	contentType, ok := Negotiate(r.Header.Get("Accept"), offers)
+	if !ok && negotiatedResponse.lenient {
+		contentType, ok = Negotiate("", offers)
+	}
+	render, registered := renderer(contentType)
+	if !ok || !registered {
+		negotiatedResponse.statusCode = http.StatusNotAcceptable
+		negotiatedResponse.content = http.StatusText(http.StatusNotAcceptable)
+		negotiatedResponse.Response.Send(w, r)
+		return
+	}
+	content, err := render(negotiatedResponse.data)
+	if err != nil && contentType != DefaultContentType() {
+		// falls back to the default content type, e.g. maps can't be encoded as XML
+		if render, registered = renderer(DefaultContentType()); registered {
+			if content, err = render(negotiatedResponse.data); err == nil {
+				contentType = DefaultContentType()
+			}
+		}
+	}
+	if err != nil {
+		panic(err)
+	}
+	negotiatedResponse.content = content
+	negotiatedResponse.SetHeader("Content-Type", contentType)
+	negotiatedResponse.Response.Send(w, r)
+}
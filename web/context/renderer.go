@@ -0,0 +1,187 @@
+package context
+
+import (
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/wardonne/gopi/support/utils"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// Renderer encodes data for a content type, see [RegisterRenderer]
+type Renderer func(data any) ([]byte, error)
+
+type rendererRegistry struct {
+	mu           sync.RWMutex
+	contentTypes []string
+	renderers    map[string]Renderer
+	defaultType  string
+}
+
+var renderers = &rendererRegistry{
+	renderers:   make(map[string]Renderer),
+	defaultType: MIMEJSON,
+}
+
+func init() {
+	RegisterRenderer(MIMEJSON, utils.JSONEncode)
+	RegisterRenderer(MIMEXML, xml.Marshal)
+	RegisterRenderer("text/xml", xml.Marshal)
+	RegisterRenderer(MIMEYAML, yaml.Marshal)
+	RegisterRenderer("application/yaml", yaml.Marshal)
+	RegisterRenderer("text/yaml", yaml.Marshal)
+	RegisterRenderer(MIMETOML, toml.Marshal)
+	RegisterRenderer(MIMEPROTOBUF, func(data any) ([]byte, error) {
+		message, ok := data.(proto.Message)
+		if !ok {
+			return nil, fmt.Errorf("%T is not a proto.Message", data)
+		}
+		return proto.Marshal(message)
+	})
+}
+
+// RegisterRenderer registers the renderer of the content type used by [Response.Negotiate],
+// the renderer of a registered content type is replaced
+//
+//	context.RegisterRenderer("application/msgpack", msgpack.Marshal)
+func RegisterRenderer(contentType string, renderer Renderer) {
+	renderers.mu.Lock()
+	defer renderers.mu.Unlock()
+	if _, ok := renderers.renderers[contentType]; !ok {
+		renderers.contentTypes = append(renderers.contentTypes, contentType)
+	}
+	renderers.renderers[contentType] = renderer
+}
+
+// ContentTypes returns the content types of the registered renderers in order of registration
+func ContentTypes() []string {
+	renderers.mu.RLock()
+	defer renderers.mu.RUnlock()
+	return append([]string(nil), renderers.contentTypes...)
+}
+
+// SetDefaultContentType sets the content type used when the Accept header is empty, default is [MIMEJSON]
+//
+// it panics if no renderer is registered for the content type.
+func SetDefaultContentType(contentType string) {
+	renderers.mu.Lock()
+	defer renderers.mu.Unlock()
+	if _, ok := renderers.renderers[contentType]; !ok {
+		panic(fmt.Errorf("renderer of %s is not registered", contentType))
+	}
+	renderers.defaultType = contentType
+}
+
+// DefaultContentType returns the default content type, see [SetDefaultContentType]
+func DefaultContentType() string {
+	renderers.mu.RLock()
+	defer renderers.mu.RUnlock()
+	return renderers.defaultType
+}
+
+func renderer(contentType string) (Renderer, bool) {
+	renderers.mu.RLock()
+	defer renderers.mu.RUnlock()
+	renderer, ok := renderers.renderers[contentType]
+	return renderer, ok
+}
+
+type mediaRange struct {
+	typ, subtype string
+	quality      float64
+}
+
+// Negotiate chooses the content type from offers by the Accept header with q-values
+//
+// the default content type is chosen if accept is empty, or it ties with other offers.
+// false is returned if none of the offers is acceptable.
+//
+//	context.Negotiate("application/xml;q=0.9, application/json", context.ContentTypes()) // application/json
+func Negotiate(accept string, offers []string) (string, bool) {
+	if len(offers) == 0 {
+		return "", false
+	}
+	defaultType := DefaultContentType()
+	if strings.TrimSpace(accept) == "" {
+		for _, offer := range offers {
+			if offer == defaultType {
+				return offer, true
+			}
+		}
+		return offers[0], true
+	}
+	ranges := parseAccept(accept)
+	type candidate struct {
+		offer       string
+		quality     float64
+		specificity int
+		position    int
+	}
+	var best *candidate
+	for _, offer := range offers {
+		typ, subtype, _ := strings.Cut(offer, "/")
+		current := candidate{offer: offer, specificity: -1}
+		// the most specific range matching the offer decides its quality
+		for position, r := range ranges {
+			specificity := -1
+			switch {
+			case r.typ == typ && r.subtype == subtype:
+				specificity = 2
+			case r.typ == typ && r.subtype == "*":
+				specificity = 1
+			case r.typ == "*" && r.subtype == "*":
+				specificity = 0
+			}
+			if specificity > current.specificity {
+				current.quality, current.specificity, current.position = r.quality, specificity, position
+			}
+		}
+		if current.specificity < 0 || current.quality <= 0 {
+			continue
+		}
+		if best == nil || current.quality > best.quality ||
+			current.quality == best.quality && (current.specificity > best.specificity ||
+				current.specificity == best.specificity && (current.position < best.position ||
+					current.position == best.position && offer == defaultType)) {
+			c := current
+			best = &c
+		}
+	}
+	if best == nil {
+		return "", false
+	}
+	return best.offer, true
+}
+
+// parseAccept parses the media ranges of the Accept header, sorted by quality descending
+func parseAccept(accept string) []mediaRange {
+	ranges := make([]mediaRange, 0)
+	for _, item := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(item))
+		if err != nil {
+			continue
+		}
+		typ, subtype, ok := strings.Cut(mediaType, "/")
+		if !ok {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{typ: typ, subtype: subtype, quality: quality})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+	return ranges
+}
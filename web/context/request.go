@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/wardonne/gopi/support/maps"
@@ -22,30 +24,53 @@ type Request struct {
 	Params  httprouter.Params
 	Values  *maps.HashMap[string, any]
 	form    validation.IValidateForm
+	urls    URLGenerator
+}
+
+// URLGenerator generates urls of named routes
+type URLGenerator interface {
+	URL(name string, params map[string]any, query url.Values) (string, error)
+	SignedURL(name string, params map[string]any, query url.Values, expires time.Time) (string, error)
 }
 
 // NewRequest creates a new [Request] instance with http.Request and httprouter.Params
+//
+// the values of the request are bound to the context of http.Request, see [Request.Context]
 func NewRequest(r *http.Request, p httprouter.Params) *Request {
-	return &Request{
-		mu:      new(sync.RWMutex),
-		Request: r,
-		Params:  p,
-		Values:  maps.NewHashMap[string, any](),
+	request := &Request{
+		mu:     new(sync.RWMutex),
+		Params: p,
+		Values: maps.NewHashMap[string, any](),
 	}
+	request.Request = r.WithContext(request.withValues(r.Context()))
+	return request
 }
 
 // Clone clones a new [Request] instance from current one
 func (request *Request) Clone() *Request {
 	return &Request{
-		mu:      new(sync.RWMutex),
+		mu:      request.mu,
 		Request: request.Request,
 		Params:  request.Params,
 		Values:  request.Values,
+		urls:    request.urls,
 	}
 }
 
+// SetURLGenerator sets the url generator of named routes
+func (request *Request) SetURLGenerator(generator URLGenerator) {
+	request.urls = generator
+}
+
+// URLGenerator returns the url generator of named routes, it's nil if the request is not dispatched by router
+func (request *Request) URLGenerator() URLGenerator {
+	return request.urls
+}
+
 // Set sets a value with specific key to current request
 func (request *Request) Set(key string, value any) {
+	request.mu.Lock()
+	defer request.mu.Unlock()
 	request.Values.Set(key, value)
 }
 
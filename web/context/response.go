@@ -225,6 +225,21 @@ func (response *Response) Protobuf(data ...any) *ProtobufResponse {
 	return protobuf
 }
 
+// Negotiate returns a response implement which sends data in the format chosen by the Accept header
+//
+//	context.NewResponse(http.StatusOK).Negotiate(users)
+func (response *Response) Negotiate(data ...any) *NegotiatedResponse {
+	negotiated := &NegotiatedResponse{
+		Response: response,
+	}
+	if len(data) > 0 {
+		negotiated.data = data[0]
+	} else {
+		negotiated.data = response.content
+	}
+	return negotiated
+}
+
 // Reader returns a Reader response implement
 func (response *Response) Reader(reader io.Reader) *ReaderResponse {
 	r := &ReaderResponse{
@@ -1,8 +1,11 @@
 package web
 
 import (
+	"errors"
 	"io"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/wardonne/gopi/web/context"
 )
@@ -17,6 +20,29 @@ func (controller *Controller) Init(request *context.Request) {
 	controller.Request = request
 }
 
+// ErrURLGeneratorEmpty the request is not dispatched by router
+var ErrURLGeneratorEmpty = errors.New("url generator is nil")
+
+// URL builds the url of the named route
+//
+//	controller.URL("users.show", map[string]any{"id": 1}, nil) // /users/1
+func (controller *Controller) URL(name string, params map[string]any, query url.Values) (string, error) {
+	if controller.URLGenerator() == nil {
+		return "", ErrURLGeneratorEmpty
+	}
+	return controller.URLGenerator().URL(name, params, query)
+}
+
+// SignedURL builds the signed url of the named route
+//
+//	controller.SignedURL("files.download", map[string]any{"id": 1}, nil, time.Now().Add(time.Hour))
+func (controller *Controller) SignedURL(name string, params map[string]any, query url.Values, expires time.Time) (string, error) {
+	if controller.URLGenerator() == nil {
+		return "", ErrURLGeneratorEmpty
+	}
+	return controller.URLGenerator().SignedURL(name, params, query, expires)
+}
+
 // Response returns a basic response
 func (controller *Controller) Response(statusCode int, content ...any) *context.Response {
 	return context.NewResponse(statusCode, content...)
@@ -47,6 +73,11 @@ func (controller *Controller) Protobuf(statusCode int, content ...any) *context.
 	return controller.Response(statusCode, content...).Protobuf()
 }
 
+// Negotiate returns a response in the format chosen by the Accept header, see [context.Negotiate]
+func (controller *Controller) Negotiate(statusCode int, content ...any) *context.NegotiatedResponse {
+	return controller.Response(statusCode, content...).Negotiate()
+}
+
 // Reader returns a reader response
 func (controller *Controller) Reader(statusCode int, r io.Reader) *context.ReaderResponse {
 	return controller.Response(statusCode).Reader(r)
@@ -0,0 +1,104 @@
+package signed
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/wardonne/gopi/pipeline"
+	"github.com/wardonne/gopi/web/context"
+	"github.com/wardonne/gopi/web/middleware"
+)
+
+// SignatureKey the query key of the signature
+const SignatureKey = "signature"
+
+// ExpiresKey the query key of the expiration unix timestamp
+const ExpiresKey = "expires"
+
+var (
+	// ErrInvalidSignature the signature is missing or doesn't match
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrSignatureExpired the signed url has expired
+	ErrSignatureExpired = errors.New("signature expired")
+)
+
+// Sign signs the path and query with key, the url never expires if expires is zero
+//
+// path should be escaped as it is sent, e.g. `/files/read%20me.md`, it's verified with [url.URL.EscapedPath]
+//
+//	query := signed.Sign("/files/1", url.Values{"download": {"1"}}, key, time.Now().Add(time.Hour))
+//	location := "/files/1?" + query.Encode()
+func Sign(path string, query url.Values, key []byte, expires time.Time) url.Values {
+	signed := make(url.Values, len(query)+2)
+	for k, v := range query {
+		if k == SignatureKey || k == ExpiresKey {
+			continue
+		}
+		signed[k] = append([]string(nil), v...)
+	}
+	if !expires.IsZero() {
+		signed.Set(ExpiresKey, strconv.FormatInt(expires.Unix(), 10))
+	}
+	signed.Set(SignatureKey, signature(path, signed, key))
+	return signed
+}
+
+// Verify verifies the signature and expiration of the url
+func Verify(u *url.URL, key []byte, now time.Time) error {
+	query := u.Query()
+	sig := query.Get(SignatureKey)
+	if sig == "" {
+		return ErrInvalidSignature
+	}
+	expected, err := hex.DecodeString(signature(u.EscapedPath(), query, key))
+	if err != nil {
+		return err
+	}
+	actual, err := hex.DecodeString(sig)
+	if err != nil || !hmac.Equal(expected, actual) {
+		return ErrInvalidSignature
+	}
+	if expires := query.Get(ExpiresKey); expires != "" {
+		timestamp, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		if now.Unix() > timestamp {
+			return ErrSignatureExpired
+		}
+	}
+	return nil
+}
+
+// New creates a middleware which rejects requests without a valid signature with 403
+//
+//	router.GET("/files/:id", download).Use(signed.New(key))
+func New(key []byte) middleware.IMiddleware {
+	return func(request *context.Request, next pipeline.Next[*context.Request, context.IResponse]) context.IResponse {
+		if err := Verify(request.Request.URL, key, time.Now()); err != nil {
+			return context.NewResponse(http.StatusForbidden, err.Error())
+		}
+		return next(request)
+	}
+}
+
+// signature returns the hex encoded HMAC-SHA256 of the path and the query without signature
+func signature(path string, query url.Values, key []byte) string {
+	values := make(url.Values, len(query))
+	for k, v := range query {
+		if k != SignatureKey {
+			values[k] = v
+		}
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(path))
+	mac.Write([]byte{'?'})
+	mac.Write([]byte(values.Encode()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
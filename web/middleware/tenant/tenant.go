@@ -0,0 +1,68 @@
+package tenant
+
+import (
+	"net"
+	"strings"
+
+	"github.com/wardonne/gopi/database/tenancy"
+	"github.com/wardonne/gopi/pipeline"
+	"github.com/wardonne/gopi/web/context"
+	"github.com/wardonne/gopi/web/middleware"
+)
+
+const defaultHeaderKey = "X-Tenant-ID"
+
+// Resolver resolves the tenant from request, the second return value reports whether the tenant is found
+type Resolver func(request *context.Request) (any, bool)
+
+// Default returns a tenant middleware which resolves the tenant from header "X-Tenant-ID"
+func Default() middleware.IMiddleware {
+	return New(FromHeader(defaultHeaderKey))
+}
+
+// New creates a new tenant middleware
+//
+// the resolved tenant is bound to the context of the request and stored in request values with key "tenant",
+// database queries built with the request context are isolated by the tenant, see [tenancy.Tenancy].
+// If the tenant is not found, the request is passed through without tenant.
+func New(resolver Resolver) middleware.IMiddleware {
+	return func(request *context.Request, next pipeline.Next[*context.Request, context.IResponse]) context.IResponse {
+		if tenant, ok := resolver(request); ok {
+			request.Set("tenant", tenant)
+			request = request.WithContext(tenancy.WithTenant(request.Context(), tenant))
+		}
+		return next(request)
+	}
+}
+
+// FromHeader resolves the tenant from the request header
+func FromHeader(key string) Resolver {
+	return func(request *context.Request) (any, bool) {
+		value := request.Header(key)
+		if value == nil || value.String() == "" {
+			return nil, false
+		}
+		return value.String(), true
+	}
+}
+
+// FromSubdomain resolves the tenant from the subdomain of domain
+//
+//	FromSubdomain("example.com") // acme.example.com => acme
+func FromSubdomain(domain string) Resolver {
+	suffix := "." + strings.TrimPrefix(domain, ".")
+	return func(request *context.Request) (any, bool) {
+		host := request.Host()
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if !strings.HasSuffix(host, suffix) {
+			return nil, false
+		}
+		subdomain := strings.TrimSuffix(host, suffix)
+		if subdomain == "" || strings.Contains(subdomain, ".") {
+			return nil, false
+		}
+		return subdomain, true
+	}
+}
@@ -0,0 +1,24 @@
+package timeout
+
+import (
+	libctx "context"
+	"time"
+
+	"github.com/wardonne/gopi/pipeline"
+	"github.com/wardonne/gopi/web/context"
+	"github.com/wardonne/gopi/web/middleware"
+)
+
+// New creates a middleware which sets the deadline of the request context
+//
+// the deadline is propagated to database queries and outbound requests built with [context.Request.Context],
+// handlers should check the context and stop working once it's done.
+//
+//	router.Use(timeout.New(5 * time.Second))
+func New(timeout time.Duration) middleware.IMiddleware {
+	return func(request *context.Request, next pipeline.Next[*context.Request, context.IResponse]) context.IResponse {
+		ctx, cancel := libctx.WithTimeout(request.Context(), timeout)
+		defer cancel()
+		return next(request.WithContext(ctx))
+	}
+}
@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version the OpenAPI version of generated documents
+const Version = "3.1.0"
+
+// Document OpenAPI document
+type Document struct {
+	OpenAPI string                           `json:"openapi" yaml:"openapi"`
+	Info    Info                             `json:"info" yaml:"info"`
+	Servers []Server                         `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Paths   map[string]map[string]*Operation `json:"paths" yaml:"paths"`
+}
+
+// Info metadata of the API
+type Info struct {
+	Title       string `json:"title" yaml:"title"`
+	Version     string `json:"version" yaml:"version"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// Server a server of the API
+type Server struct {
+	URL         string `json:"url" yaml:"url"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// Operation an API operation of a route
+type Operation struct {
+	OperationID string              `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Summary     string              `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Parameters  []*Parameter        `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses" yaml:"responses"`
+}
+
+// Parameter a path or query parameter
+type Parameter struct {
+	Name     string  `json:"name" yaml:"name"`
+	In       string  `json:"in" yaml:"in"`
+	Required bool    `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema   *Schema `json:"schema" yaml:"schema"`
+}
+
+// RequestBody the request body of an operation
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty" yaml:"required,omitempty"`
+	Content  map[string]MediaType `json:"content" yaml:"content"`
+}
+
+// MediaType the schema of a content type
+type MediaType struct {
+	Schema *Schema `json:"schema" yaml:"schema"`
+}
+
+// Response a response of an operation
+type Response struct {
+	Description string `json:"description" yaml:"description"`
+}
+
+// JSON returns the document encoded in json
+func (document *Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// YAML returns the document encoded in yaml
+func (document *Document) YAML() ([]byte, error) {
+	return yaml.Marshal(document)
+}
+
+// WriteFile writes the document to the file, it's encoded in yaml if the extension is .yaml or .yml,
+// otherwise it's encoded in json
+func (document *Document) WriteFile(filename string) error {
+	var content []byte
+	var err error
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		content, err = document.YAML()
+	default:
+		content, err = document.JSON()
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, content, 0o644)
+}
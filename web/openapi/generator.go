@@ -0,0 +1,162 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/wardonne/gopi/validation"
+	"github.com/wardonne/gopi/web/binding"
+)
+
+// Route the route metadata used to generate operations
+type Route interface {
+	Name() string
+	Method() string
+	Path() string
+	Form() (validation.IValidateForm, []binding.Binding)
+}
+
+// body content types of bindings which decode request body
+var bodyBindings = []struct {
+	binding     binding.Binding
+	contentType string
+	tag         string
+}{
+	{binding.JSON, "application/json", "json"},
+	{binding.XML, "application/xml", "xml"},
+	{binding.YAML, "application/yaml", "yaml"},
+	{binding.TOML, "application/toml", "toml"},
+}
+
+// Generate generates the OpenAPI document of the routes
+//
+//	document := openapi.Generate(openapi.Info{Title: "API", Version: "1.0.0"}, routes...)
+func Generate(info Info, routes ...Route) *Document {
+	document := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]map[string]*Operation),
+	}
+	for _, route := range routes {
+		path := Path(route.Path())
+		if document.Paths[path] == nil {
+			document.Paths[path] = make(map[string]*Operation)
+		}
+		document.Paths[path][strings.ToLower(route.Method())] = NewOperation(route)
+	}
+	return document
+}
+
+// Path converts the httprouter pattern to the OpenAPI path
+//
+//	openapi.Path("/users/:id/files/*path") // /users/{id}/files/{path}
+func Path(pattern string) string {
+	segments := strings.Split(pattern, "/")
+	for idx, segment := range segments {
+		if len(segment) > 1 && (segment[0] == ':' || segment[0] == '*') {
+			segments[idx] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// NewOperation creates the operation of the route
+//
+// path params come from the route pattern, their schemas come from `param` tags of the form.
+// Fields with `form` tag are query params of GET, HEAD, DELETE and OPTIONS routes,
+// and form body of other routes. Bindings of json, xml, yaml and toml add body content types.
+// If no binding is provided, the form is bound by [binding.Form] or by the Content-Type.
+func NewOperation(route Route) *Operation {
+	operation := &Operation{
+		OperationID: route.Name(),
+		Responses: map[string]Response{
+			"200": {Description: http.StatusText(http.StatusOK)},
+		},
+	}
+	form, bindings := route.Form()
+	var formType reflect.Type
+	if form != nil {
+		formType = reflect.TypeOf(form)
+		operation.Responses["400"] = Response{Description: http.StatusText(http.StatusBadRequest)}
+	}
+
+	params := make(map[string]Field)
+	if formType != nil {
+		for _, field := range Fields(formType, "param") {
+			params[field.Name] = field
+		}
+	}
+	for _, segment := range strings.Split(route.Path(), "/") {
+		if len(segment) < 2 || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		schema := &Schema{Type: "string"}
+		if field, ok := params[segment[1:]]; ok {
+			schema = field.Schema
+		}
+		operation.Parameters = append(operation.Parameters, &Parameter{
+			Name:     segment[1:],
+			In:       "path",
+			Required: true,
+			Schema:   schema,
+		})
+	}
+	if formType == nil {
+		return operation
+	}
+
+	hasForm := len(bindings) == 0 || hasBinding(bindings, binding.Form)
+	if hasForm && !hasBody(route.Method()) {
+		for _, field := range Fields(formType, "form") {
+			operation.Parameters = append(operation.Parameters, &Parameter{
+				Name:     field.Name,
+				In:       "query",
+				Required: field.Required,
+				Schema:   field.Schema,
+			})
+		}
+	}
+	if !hasBody(route.Method()) {
+		return operation
+	}
+	content := make(map[string]MediaType)
+	for _, body := range bodyBindings {
+		if hasBinding(bindings, body.binding) || (len(bindings) == 0 && body.tag == "json") {
+			content[body.contentType] = MediaType{Schema: SchemaOf(formType, body.tag)}
+		}
+	}
+	if hasForm {
+		contentType := "application/x-www-form-urlencoded"
+		if HasFile(formType, "form") {
+			contentType = "multipart/form-data"
+		}
+		content[contentType] = MediaType{Schema: SchemaOf(formType, "form")}
+	}
+	if len(content) > 0 {
+		required := false
+		for _, media := range content {
+			required = required || len(media.Schema.Required) > 0
+		}
+		operation.RequestBody = &RequestBody{Required: required, Content: content}
+	}
+	return operation
+}
+
+func hasBody(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+func hasBinding(bindings []binding.Binding, target binding.Binding) bool {
+	pointer := reflect.ValueOf(target).Pointer()
+	for _, b := range bindings {
+		if reflect.ValueOf(b).Pointer() == pointer {
+			return true
+		}
+	}
+	return false
+}
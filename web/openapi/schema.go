@@ -0,0 +1,314 @@
+package openapi
+
+import (
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wardonne/gopi/web/context/formdata"
+)
+
+// Schema JSON Schema of a value
+type Schema struct {
+	Type                 string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Format               string             `json:"format,omitempty" yaml:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Enum                 []any              `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+	MinLength            *uint64            `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength            *uint64            `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	MinItems             *uint64            `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems             *uint64            `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// Field a field of a struct in the schema
+type Field struct {
+	Name     string
+	Required bool
+	Schema   *Schema
+}
+
+var (
+	timeType           = reflect.TypeOf(time.Time{})
+	fileHeaderType     = reflect.TypeOf(multipart.FileHeader{})
+	uploadedFileType   = reflect.TypeOf(formdata.UploadedFile{})
+	formdataValueType  = reflect.TypeOf(formdata.Value(""))
+	formdataValuesType = reflect.TypeOf(formdata.Values{})
+)
+
+// SchemaOf returns the schema of the type, struct fields are named by the tag, e.g. json, xml, form
+//
+//	openapi.SchemaOf(reflect.TypeOf(CreateUserForm{}), "json")
+func SchemaOf(t reflect.Type, tag string) *Schema {
+	return schemaOf(t, tag, make(map[reflect.Type]bool))
+}
+
+// Fields returns the fields of the struct named by the tag
+//
+// fields without the tag are skipped unless the tag is one of json, xml, yaml and toml,
+// fields of embedded structs are promoted.
+func Fields(t reflect.Type, tag string) []Field {
+	return fields(t, tag, make(map[reflect.Type]bool))
+}
+
+// HasFile returns whether the struct has file fields named by the tag
+func HasFile(t reflect.Type, tag string) bool {
+	for _, field := range Fields(t, tag) {
+		if field.Schema.Format == "binary" || (field.Schema.Items != nil && field.Schema.Items.Format == "binary") {
+			return true
+		}
+	}
+	return false
+}
+
+func schemaOf(t reflect.Type, tag string, seen map[reflect.Type]bool) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case fileHeaderType, uploadedFileType:
+		return &Schema{Type: "string", Format: "binary"}
+	case formdataValueType:
+		return &Schema{Type: "string"}
+	case formdataValuesType:
+		return &Schema{Type: "array", Items: &Schema{Type: "string"}}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := float64(0)
+		return &Schema{Type: "integer", Minimum: &zero}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: schemaOf(t.Elem(), tag, seen)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaOf(t.Elem(), tag, seen)}
+	case reflect.Struct:
+		if seen[t] {
+			return &Schema{Type: "object"}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		for _, field := range fields(t, tag, seen) {
+			schema.Properties[field.Name] = field.Schema
+			if field.Required {
+				schema.Required = append(schema.Required, field.Name)
+			}
+		}
+		return schema
+	default:
+		return &Schema{}
+	}
+}
+
+func fields(t reflect.Type, tag string, seen map[reflect.Type]bool) []Field {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	result := make([]Field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.TrimSpace(strings.Split(field.Tag.Get(tag), ",")[0])
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				result = append(result, fields(embedded, tag, seen)...)
+				continue
+			}
+		}
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			if !namedByFieldName(tag) {
+				continue
+			}
+			name = field.Name
+		}
+		schema := schemaOf(field.Type, tag, seen)
+		required := applyRules(schema, field.Type, field.Tag.Get("validate"))
+		result = append(result, Field{Name: name, Required: required, Schema: schema})
+	}
+	return result
+}
+
+// namedByFieldName returns whether the fields without the tag are named by the field name when decoding
+func namedByFieldName(tag string) bool {
+	switch tag {
+	case "json", "xml", "yaml", "toml":
+		return true
+	}
+	return false
+}
+
+// applyRules applies the validate rules to the schema and returns whether the field is required
+//
+// rules after `dive` are applied to the items of slices
+func applyRules(schema *Schema, t reflect.Type, rules string) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	required := false
+	parts := strings.Split(rules, ",")
+	for idx, rule := range parts {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if strings.Contains(name, "|") {
+			continue
+		}
+		if name == "dive" {
+			if schema.Items != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+				applyRules(schema.Items, t.Elem(), strings.Join(parts[idx+1:], ","))
+			}
+			break
+		}
+		switch name {
+		case "required":
+			required = true
+		case "min", "gte":
+			setBound(schema, t, param, boundMin, 0)
+		case "max", "lte":
+			setBound(schema, t, param, boundMax, 0)
+		case "len", "eq":
+			if t.Kind() == reflect.String || isCollection(t) {
+				setBound(schema, t, param, boundMin, 0)
+				setBound(schema, t, param, boundMax, 0)
+			} else if name == "eq" {
+				schema.Enum = enum(t, param)
+			}
+		case "gt":
+			setBound(schema, t, param, boundExclusiveMin, 1)
+		case "lt":
+			setBound(schema, t, param, boundExclusiveMax, -1)
+		case "oneof":
+			schema.Enum = enum(t, param)
+		case "email":
+			schema.Format = "email"
+		case "url", "uri", "http_url":
+			schema.Format = "uri"
+		case "uuid", "uuid3", "uuid4", "uuid5":
+			schema.Format = "uuid"
+		case "ipv4", "ip4_addr":
+			schema.Format = "ipv4"
+		case "ipv6", "ip6_addr":
+			schema.Format = "ipv6"
+		case "hostname", "hostname_rfc1123":
+			schema.Format = "hostname"
+		case "alpha":
+			schema.Pattern = "^[a-zA-Z]+$"
+		case "alphanum":
+			schema.Pattern = "^[a-zA-Z0-9]+$"
+		case "numeric":
+			schema.Pattern = `^[-+]?[0-9]+(?:\.[0-9]+)?$`
+		}
+	}
+	return required
+}
+
+type bound int
+
+const (
+	boundMin bound = iota
+	boundMax
+	boundExclusiveMin
+	boundExclusiveMax
+)
+
+// setBound sets the bound of numbers, or the bound of lengths of strings and collections,
+// offset converts exclusive bounds of lengths to inclusive ones
+func setBound(schema *Schema, t reflect.Type, param string, kind bound, offset int64) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	if t.Kind() == reflect.String || isCollection(t) {
+		length := int64(value) + offset
+		if length < 0 {
+			return
+		}
+		size := uint64(length)
+		isMin := kind == boundMin || kind == boundExclusiveMin
+		switch {
+		case t.Kind() == reflect.String && isMin:
+			schema.MinLength = &size
+		case t.Kind() == reflect.String:
+			schema.MaxLength = &size
+		case isMin:
+			schema.MinItems = &size
+		default:
+			schema.MaxItems = &size
+		}
+		return
+	}
+	switch kind {
+	case boundMin:
+		schema.Minimum = &value
+	case boundMax:
+		schema.Maximum = &value
+	case boundExclusiveMin:
+		schema.ExclusiveMinimum = &value
+	case boundExclusiveMax:
+		schema.ExclusiveMaximum = &value
+	}
+}
+
+func isCollection(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map
+}
+
+// enum converts the space separated values to the type of the field
+func enum(t reflect.Type, param string) []any {
+	values := strings.Fields(param)
+	result := make([]any, 0, len(values))
+	for _, value := range values {
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+				result = append(result, v)
+				continue
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if v, err := strconv.ParseUint(value, 10, 64); err == nil {
+				result = append(result, v)
+				continue
+			}
+		case reflect.Float32, reflect.Float64:
+			if v, err := strconv.ParseFloat(value, 64); err == nil {
+				result = append(result, v)
+				continue
+			}
+		}
+		result = append(result, value)
+	}
+	return result
+}
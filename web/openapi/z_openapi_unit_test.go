@@ -0,0 +1,141 @@
+package openapi
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/validation"
+	"github.com/wardonne/gopi/web/binding"
+	"github.com/wardonne/gopi/web/context/formdata"
+	"gopkg.in/yaml.v3"
+)
+
+type address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type userform struct {
+	validation.Form
+	ID      int                    `json:"-" param:"id" validate:"required,gt=0"`
+	Name    string                 `json:"name" form:"name" validate:"required,min=2,max=20"`
+	Email   string                 `json:"email" form:"email" validate:"omitempty,email"`
+	Age     *uint8                 `json:"age" form:"age" validate:"gte=18,lte=120"`
+	Role    string                 `json:"role" form:"role" validate:"oneof=admin member"`
+	Level   int                    `json:"level" validate:"oneof=1 2 3"`
+	Tags    []string               `json:"tags" validate:"max=3,dive,min=1"`
+	Address *address               `json:"address"`
+	Secret  string                 `json:"-"`
+	Avatar  *formdata.UploadedFile `json:"-" form:"avatar"`
+	Extra   map[string]float64     `json:"extra"`
+	Hidden  string
+}
+
+type route struct {
+	name, method, path string
+	form               validation.IValidateForm
+	bindings           []binding.Binding
+}
+
+func (r route) Name() string   { return r.name }
+func (r route) Method() string { return r.method }
+func (r route) Path() string   { return r.path }
+func (r route) Form() (validation.IValidateForm, []binding.Binding) {
+	return r.form, r.bindings
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestSchemaOf(t *testing.T) {
+	schema := SchemaOf(reflect.TypeOf(new(userform)), "json")
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, []string{"name"}, schema.Required)
+	assert.NotContains(t, schema.Properties, "Secret")
+	assert.NotContains(t, schema.Properties, "ID")
+	assert.Contains(t, schema.Properties, "Hidden")
+
+	assert.Equal(t, &Schema{Type: "string", MinLength: ptr(uint64(2)), MaxLength: ptr(uint64(20))}, schema.Properties["name"])
+	assert.Equal(t, &Schema{Type: "string", Format: "email"}, schema.Properties["email"])
+	assert.Equal(t, &Schema{Type: "integer", Minimum: ptr(18.0), Maximum: ptr(120.0)}, schema.Properties["age"])
+	assert.Equal(t, []any{"admin", "member"}, schema.Properties["role"].Enum)
+	assert.Equal(t, []any{int64(1), int64(2), int64(3)}, schema.Properties["level"].Enum)
+	assert.Equal(t, &Schema{Type: "array", MaxItems: ptr(uint64(3)), Items: &Schema{Type: "string", MinLength: ptr(uint64(1))}}, schema.Properties["tags"])
+	assert.Equal(t, []string{"city"}, schema.Properties["address"].Required)
+	assert.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "number", Format: "double"}}, schema.Properties["extra"])
+
+	form := SchemaOf(reflect.TypeOf(new(userform)), "form")
+	assert.Equal(t, []string{"name", "email", "age", "role", "avatar"}, keys(Fields(reflect.TypeOf(new(userform)), "form")))
+	assert.Equal(t, &Schema{Type: "string", Format: "binary"}, form.Properties["avatar"])
+	assert.True(t, HasFile(reflect.TypeOf(new(userform)), "form"))
+
+	params := Fields(reflect.TypeOf(new(userform)), "param")
+	assert.Equal(t, []Field{{Name: "id", Required: true, Schema: &Schema{Type: "integer", Format: "int64", ExclusiveMinimum: ptr(0.0)}}}, params)
+}
+
+func keys(fields []Field) []string {
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+	return names
+}
+
+func TestGenerate(t *testing.T) {
+	document := Generate(Info{Title: "API", Version: "1.0.0"},
+		route{name: "users.index", method: http.MethodGet, path: "/users", form: new(userform)},
+		route{name: "users.update", method: http.MethodPut, path: "/users/:id", form: new(userform), bindings: []binding.Binding{binding.URI, binding.JSON}},
+		route{method: http.MethodPost, path: "/users/:id/avatar", form: new(userform), bindings: []binding.Binding{binding.Form}},
+		route{method: http.MethodGet, path: "/files/*path"},
+	)
+	assert.Equal(t, Version, document.OpenAPI)
+	assert.Equal(t, "API", document.Info.Title)
+
+	index := document.Paths["/users"]["get"]
+	assert.Equal(t, "users.index", index.OperationID)
+	assert.Nil(t, index.RequestBody)
+	assert.Len(t, index.Parameters, 5)
+	assert.Equal(t, &Parameter{Name: "name", In: "query", Required: true, Schema: &Schema{Type: "string", MinLength: ptr(uint64(2)), MaxLength: ptr(uint64(20))}}, index.Parameters[0])
+	assert.Contains(t, index.Responses, "400")
+
+	update := document.Paths["/users/{id}"]["put"]
+	assert.Equal(t, "path", update.Parameters[0].In)
+	assert.Equal(t, "integer", update.Parameters[0].Schema.Type)
+	assert.Len(t, update.Parameters, 1)
+	assert.True(t, update.RequestBody.Required)
+	assert.Equal(t, []string{"application/json"}, contentTypes(update.RequestBody))
+
+	avatar := document.Paths["/users/{id}/avatar"]["post"]
+	assert.Equal(t, []string{"multipart/form-data"}, contentTypes(avatar.RequestBody))
+
+	files := document.Paths["/files/{path}"]["get"]
+	assert.Equal(t, []*Parameter{{Name: "path", In: "path", Required: true, Schema: &Schema{Type: "string"}}}, files.Parameters)
+	assert.Equal(t, map[string]Response{"200": {Description: "OK"}}, files.Responses)
+}
+
+func contentTypes(body *RequestBody) []string {
+	types := make([]string, 0, len(body.Content))
+	for contentType := range body.Content {
+		types = append(types, contentType)
+	}
+	return types
+}
+
+func TestDocument_WriteFile(t *testing.T) {
+	document := Generate(Info{Title: "API", Version: "1.0.0"}, route{method: http.MethodGet, path: "/ping"})
+	dir := t.TempDir()
+
+	assert.Nil(t, document.WriteFile(filepath.Join(dir, "openapi.yaml")))
+	content, err := os.ReadFile(filepath.Join(dir, "openapi.yaml"))
+	assert.Nil(t, err)
+	decoded := make(map[string]any)
+	assert.Nil(t, yaml.Unmarshal(content, &decoded))
+	assert.Equal(t, Version, decoded["openapi"])
+
+	assert.Nil(t, document.WriteFile(filepath.Join(dir, "openapi.json")))
+	content, err = os.ReadFile(filepath.Join(dir, "openapi.json"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), `"/ping"`)
+}
@@ -0,0 +1,83 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"reflect"
+
+	"github.com/wardonne/gopi/web/context"
+)
+
+// MIMECSV the content type of CSV
+const MIMECSV = "text/csv"
+
+// Register registers [Render] as the renderer of [MIMECSV]
+//
+//	csv.Register()
+//	return context.NewResponse(http.StatusOK).Negotiate(users)
+func Register() {
+	context.RegisterRenderer(MIMECSV, Render)
+}
+
+// Render encodes [][]string, or slices of structs as CSV
+//
+// the header of structs is the `csv` tag or the name of exported fields, fields tagged with `csv:"-"` are skipped.
+func Render(data any) ([]byte, error) {
+	records, err := toRecords(data)
+	if err != nil {
+		return nil, err
+	}
+	buffer := new(bytes.Buffer)
+	writer := csv.NewWriter(buffer)
+	if err := writer.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func toRecords(data any) ([][]string, error) {
+	if records, ok := data.([][]string); ok {
+		return records, nil
+	}
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, fmt.Errorf("csv: %T can't be rendered, expected [][]string or a slice of structs", data)
+	}
+	elemType := value.Type().Elem()
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csv: %T can't be rendered, expected [][]string or a slice of structs", data)
+	}
+	header, indexes := make([]string, 0), make([]int, 0)
+	for i := 0; i < elemType.NumField(); i++ {
+		field := elemType.Field(i)
+		name := field.Tag.Get("csv")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		header, indexes = append(header, name), append(indexes, i)
+	}
+	records := [][]string{header}
+	for i := 0; i < value.Len(); i++ {
+		item := value.Index(i)
+		for item.Kind() == reflect.Ptr {
+			item = item.Elem()
+		}
+		record := make([]string, 0, len(indexes))
+		for _, index := range indexes {
+			if !item.IsValid() {
+				record = append(record, "")
+				continue
+			}
+			record = append(record, fmt.Sprint(item.Field(index).Interface()))
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
@@ -0,0 +1,371 @@
+package router
+
+import (
+	libctx "context"
+	"encoding"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wardonne/gopi/container"
+	"github.com/wardonne/gopi/validation"
+	"github.com/wardonne/gopi/web/binding"
+	"github.com/wardonne/gopi/web/context"
+)
+
+var (
+	// ErrInvalidParam a path param can't be converted to the type of the handler's param
+	ErrInvalidParam = errors.New("invalid path param")
+	// ErrContainerEmpty the handler depends on services, but no container is set, see [Router.SetContainer]
+	ErrContainerEmpty = errors.New("container is nil, please call SetContainer to set it first")
+)
+
+var (
+	handlerType         = reflect.TypeOf((Handler)(nil))
+	requestType         = reflect.TypeOf((*context.Request)(nil))
+	contextType         = reflect.TypeOf((*libctx.Context)(nil)).Elem()
+	responseType        = reflect.TypeOf((*context.IResponse)(nil)).Elem()
+	errorType           = reflect.TypeOf((*error)(nil)).Elem()
+	formType            = reflect.TypeOf((*validation.IValidateForm)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// ValidationError the form of the handler fails the validation
+type ValidationError struct {
+	Errors map[string][]string
+}
+
+// Error implements error
+func (err *ValidationError) Error() string {
+	return "the given data was invalid"
+}
+
+// StatusCode returns [http.StatusUnprocessableEntity]
+func (err *ValidationError) StatusCode() int {
+	return http.StatusUnprocessableEntity
+}
+
+// BindError the form of the handler can't be bound from the request, e.g. the body is malformed
+type BindError struct {
+	Err error
+}
+
+// Error implements error
+func (err *BindError) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap returns the error of binding
+func (err *BindError) Unwrap() error {
+	return err.Err
+}
+
+// StatusCode returns [http.StatusBadRequest]
+func (err *BindError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+type paramKind int
+
+const (
+	paramRequest paramKind = iota
+	paramContext
+	paramPath
+	paramForm
+	paramService
+)
+
+type handlerParam struct {
+	kind  paramKind
+	typ   reflect.Type
+	index int
+}
+
+// handlerBinding binds the params of the handler from the request and renders its returns
+//
+// params of handlers can be in any order:
+//   - *context.Request and context.Context are the request and its context
+//   - strings, bools, numbers and [encoding.TextUnmarshaler] are converted from path params by order,
+//     names of the params are not available by reflection, so they're never matched by name
+//   - pointers of [validation.IValidateForm] are bound and validated, path params are bound by `param` tags,
+//     [BindError] is rendered if the request can't be bound
+//   - other pointers and interfaces are resolved from the container of the request,
+//     [ErrContainerEmpty] is rendered if the router has no container
+//
+// handlers return nothing, an error, a value, or a value and an error,
+// values are rendered by [context.Response.Negotiate] unless they're [context.IResponse],
+// errors are rendered by [contract.ErrorHandler].
+type handlerBinding struct {
+	router     *Router
+	params     []handlerParam
+	returnsErr bool
+	returnsVal bool
+}
+
+// newHandlerBinding validates the handler's type and creates a [handlerBinding], the first offset params are skipped
+//
+// it panics if the handler can't be bound.
+func newHandlerBinding(router *Router, fnType reflect.Type, offset int, path string) *handlerBinding {
+	if fnType.Kind() != reflect.Func {
+		panic(fmt.Errorf("handler should be a function, got %s", fnType))
+	}
+	if fnType.IsVariadic() {
+		panic(fmt.Errorf("handler %s can't be variadic", fnType))
+	}
+	b := &handlerBinding{router: router}
+	pathParams := 0
+	for i := offset; i < fnType.NumIn(); i++ {
+		param := handlerParam{typ: fnType.In(i)}
+		switch {
+		case param.typ == requestType:
+			param.kind = paramRequest
+		case param.typ == contextType:
+			param.kind = paramContext
+		case isScalar(param.typ):
+			// path params are bound in order of appearance
+			param.kind, param.index = paramPath, pathParams
+			pathParams++
+		case param.typ.Kind() == reflect.Ptr && param.typ.Elem().Kind() == reflect.Struct && param.typ.Implements(formType):
+			if router.validateEngine == nil {
+				panic(ErrValidateEngineEmpty)
+			}
+			param.kind = paramForm
+		case param.typ.Kind() == reflect.Ptr || param.typ.Kind() == reflect.Interface:
+			param.kind = paramService
+		default:
+			panic(fmt.Errorf("param %d of handler %s is not supported", i, fnType))
+		}
+		b.params = append(b.params, param)
+	}
+	if pathParams > countPathParams(path) {
+		panic(fmt.Errorf("handler %s receives more path params than %s has", fnType, path))
+	}
+
+	switch fnType.NumOut() {
+	case 0:
+	case 1:
+		if fnType.Out(0) == errorType {
+			b.returnsErr = true
+		} else {
+			b.returnsVal = true
+		}
+	case 2:
+		if fnType.Out(1) != errorType {
+			panic(fmt.Errorf("the second return of handler %s should be error", fnType))
+		}
+		b.returnsVal, b.returnsErr = true, true
+	default:
+		panic(fmt.Errorf("handler %s returns too many values", fnType))
+	}
+	return b
+}
+
+// form returns a new form of the handler's form param, nil is returned if there is no form param
+func (b *handlerBinding) form() validation.IValidateForm {
+	for _, param := range b.params {
+		if param.kind == paramForm {
+			return reflect.New(param.typ.Elem()).Interface().(validation.IValidateForm)
+		}
+	}
+	return nil
+}
+
+// call calls the handler with the params bound from the request and renders the returns
+func (b *handlerBinding) call(request *context.Request, fn reflect.Value) context.IResponse {
+	args := make([]reflect.Value, 0, len(b.params))
+	for _, param := range b.params {
+		arg, err := b.bind(request, param)
+		if err != nil {
+			return b.router.renderError(request, err)
+		}
+		args = append(args, arg)
+	}
+	outputs := fn.Call(args)
+	if b.returnsErr {
+		if err := outputs[len(outputs)-1]; !err.IsNil() {
+			return b.router.renderError(request, err.Interface().(error))
+		}
+	}
+	if !b.returnsVal {
+		return context.NewResponse(http.StatusNoContent)
+	}
+	if response, ok := outputs[0].Interface().(context.IResponse); ok {
+		return response
+	}
+	return context.NewResponse(http.StatusOK).Negotiate(outputs[0].Interface())
+}
+
+func (b *handlerBinding) bind(request *context.Request, param handlerParam) (reflect.Value, error) {
+	switch param.kind {
+	case paramRequest:
+		return reflect.ValueOf(request), nil
+	case paramContext:
+		return reflect.ValueOf(request.Context()), nil
+	case paramPath:
+		if param.index >= len(request.Params) {
+			return reflect.Value{}, fmt.Errorf("%w: missing path param %d", ErrInvalidParam, param.index)
+		}
+		return convertParam(request.Params[param.index].Value, param.typ)
+	case paramForm:
+		return b.bindForm(request, param.typ)
+	default:
+		c, ok := container.FromContext(request.Context())
+		if !ok {
+			return reflect.Value{}, ErrContainerEmpty
+		}
+		return c.Resolve(param.typ)
+	}
+}
+
+// bindForm binds the form, the form validated by [Route.Validate] is reused if it's the same type
+func (b *handlerBinding) bindForm(request *context.Request, typ reflect.Type) (reflect.Value, error) {
+	form, ok := request.Validated().(validation.IValidateForm)
+	if !ok || reflect.TypeOf(form) != typ {
+		form = reflect.New(typ.Elem()).Interface().(validation.IValidateForm)
+		if err := request.Bind(form); err != nil {
+			return reflect.Value{}, &BindError{Err: err}
+		}
+		if err := binding.URI(request.Request, form); err != nil {
+			return reflect.Value{}, &BindError{Err: err}
+		}
+		form.SetEngine(b.router.validateEngine)
+		form.SetLocale(*request.GetString("language", "en"))
+		if form.AutoValidate() {
+			if form.BeforeValidate() {
+				form.Validate(form)
+			} else {
+				form.AddError("onBeforeValidate", "BeforeValidate returned false")
+			}
+		}
+	}
+	if form.Fails() {
+		return reflect.Value{}, &ValidationError{Errors: form.Errors()}
+	}
+	return reflect.ValueOf(form), nil
+}
+
+// renderError renders the error by the error handler, see [Router.SetErrorHandler]
+//
+// without error handler, errors with a `StatusCode() int` method are rendered with their status codes,
+// and other errors are rendered as [http.StatusInternalServerError] without details.
+func (router *Router) renderError(request *context.Request, err error) context.IResponse {
+	if router.errorHandler != nil {
+		return router.errorHandler.Render(request.Request, err)
+	}
+	status := http.StatusInternalServerError
+	var coder interface{ StatusCode() int }
+	if errors.As(err, &coder) {
+		status = coder.StatusCode()
+	} else if errors.Is(err, ErrInvalidParam) {
+		status = http.StatusBadRequest
+	}
+	body := errorBody{Message: http.StatusText(status)}
+	if status < http.StatusInternalServerError {
+		body.Message = err.Error()
+	}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		body.Errors = validationErr.Errors
+	}
+	return context.NewResponse(status).Negotiate(body).Lenient()
+}
+
+// errorBody the body of error responses, it's encodable by every built-in renderer
+type errorBody struct {
+	XMLName xml.Name    `json:"-" xml:"error"`
+	Message string      `json:"message" xml:"message"`
+	Errors  fieldErrors `json:"errors,omitempty" xml:"errors,omitempty"`
+}
+
+// fieldErrors the messages of invalid fields, fields are sorted by name in XML
+type fieldErrors map[string][]string
+
+// MarshalXML encodes the errors as `<field name="name"><message>...</message></field>` elements
+func (errs fieldErrors) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	fields := make([]string, 0, len(errs))
+	for field := range errs {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		element := struct {
+			Name     string   `xml:"name,attr"`
+			Messages []string `xml:"message"`
+		}{Name: field, Messages: errs[field]}
+		if err := e.EncodeElement(element, xml.StartElement{Name: xml.Name{Local: "field"}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+func isScalar(typ reflect.Type) bool {
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// convertParam converts the path param to the type
+func convertParam(value string, typ reflect.Type) (reflect.Value, error) {
+	result := reflect.New(typ)
+	if unmarshaler, ok := result.Interface().(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(value)); err != nil {
+			return reflect.Value{}, fmt.Errorf("%w: %q: %s", ErrInvalidParam, value, err)
+		}
+		return result.Elem(), nil
+	}
+	var err error
+	elem := result.Elem()
+	switch typ.Kind() {
+	case reflect.String:
+		elem.SetString(value)
+	case reflect.Bool:
+		var v bool
+		v, err = strconv.ParseBool(value)
+		elem.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var v int64
+		v, err = strconv.ParseInt(value, 10, typ.Bits())
+		elem.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var v uint64
+		v, err = strconv.ParseUint(value, 10, typ.Bits())
+		elem.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		var v float64
+		v, err = strconv.ParseFloat(value, typ.Bits())
+		elem.SetFloat(v)
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("%w: %q is not a valid %s", ErrInvalidParam, value, typ)
+	}
+	return elem, nil
+}
+
+// countPathParams counts the named and catch-all params of the path
+func countPathParams(path string) int {
+	count := 0
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,146 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/wardonne/gopi/pipeline"
+	"github.com/wardonne/gopi/web/context"
+	"github.com/wardonne/gopi/web/middleware"
+)
+
+// ErrMiddlewareNotFound no middleware alias or group has the given name
+var ErrMiddlewareNotFound = errors.New("middleware not found")
+
+// middlewareEntry a middleware or the name of a middleware alias or group
+type middlewareEntry struct {
+	name    string
+	handler middleware.IMiddleware
+}
+
+// newMiddlewareEntries converts names and middlewares to entries, it panics on other types
+func newMiddlewareEntries(middlewares ...any) []middlewareEntry {
+	entries := make([]middlewareEntry, 0, len(middlewares))
+	for _, value := range middlewares {
+		switch v := value.(type) {
+		case string:
+			entries = append(entries, middlewareEntry{name: v})
+		case middleware.IMiddleware:
+			entries = append(entries, middlewareEntry{handler: v})
+		case func(*context.Request, pipeline.Next[*context.Request, context.IResponse]) context.IResponse:
+			entries = append(entries, middlewareEntry{handler: v})
+		default:
+			panic(fmt.Errorf("invalid middleware type %T", value))
+		}
+	}
+	return entries
+}
+
+// middlewareStack the middlewares of a group or a route
+//
+// every group and route owns its stack, so adding middlewares to a sub group never changes its parent or siblings.
+// Stacks are resolved with their parents when compiling, ordered parent -> child -> route.
+type middlewareStack struct {
+	parent   *middlewareStack
+	entries  []middlewareEntry
+	excluded []string
+}
+
+func newMiddlewareStack(parent *middlewareStack) *middlewareStack {
+	return &middlewareStack{parent: parent}
+}
+
+func (stack *middlewareStack) use(entries ...middlewareEntry) {
+	stack.entries = append(stack.entries, entries...)
+}
+
+func (stack *middlewareStack) without(names ...string) {
+	stack.excluded = append(stack.excluded, names...)
+}
+
+// resolvedMiddleware a resolved middleware with the names of aliases and groups it comes from
+type resolvedMiddleware struct {
+	names   []string
+	handler middleware.IMiddleware
+}
+
+// resolve resolves the middlewares of the stack and its parents, excluded middlewares are removed
+func (stack *middlewareStack) resolve(router *Router) []middleware.IMiddleware {
+	chain := make([]*middlewareStack, 0)
+	for s := stack; s != nil; s = s.parent {
+		chain = append([]*middlewareStack{s}, chain...)
+	}
+	resolved := make([]resolvedMiddleware, 0)
+	excluded := make([]string, 0)
+	for _, s := range chain {
+		for _, entry := range s.entries {
+			resolved = append(resolved, router.resolveMiddleware(entry, nil, 0)...)
+		}
+		excluded = append(excluded, s.excluded...)
+	}
+	middlewares := make([]middleware.IMiddleware, 0, len(resolved))
+	for _, item := range resolved {
+		if !isExcluded(item, excluded) {
+			middlewares = append(middlewares, item.handler)
+		}
+	}
+	return middlewares
+}
+
+// maxMiddlewareGroupDepth avoids infinite recursion of middleware groups referencing each other
+const maxMiddlewareGroupDepth = 32
+
+func (router *Router) resolveMiddleware(entry middlewareEntry, names []string, depth int) []resolvedMiddleware {
+	if entry.handler != nil {
+		return []resolvedMiddleware{{names: names, handler: entry.handler}}
+	}
+	if depth > maxMiddlewareGroupDepth {
+		panic(fmt.Errorf("middleware group %q is nested too deeply", entry.name))
+	}
+	names = append(append([]string(nil), names...), entry.name)
+	if handler, ok := router.middlewareAliases[entry.name]; ok {
+		return []resolvedMiddleware{{names: names, handler: handler}}
+	}
+	group, ok := router.middlewareGroups[entry.name]
+	if !ok {
+		panic(fmt.Errorf("%w: %s", ErrMiddlewareNotFound, entry.name))
+	}
+	resolved := make([]resolvedMiddleware, 0, len(group))
+	for _, item := range group {
+		resolved = append(resolved, router.resolveMiddleware(item, names, depth+1)...)
+	}
+	return resolved
+}
+
+// isExcluded returns whether the middleware comes from an excluded alias or group
+//
+// middlewares are excluded by names only, functions can't be compared,
+// register a middleware by [Router.AliasMiddleware] to make it excludable.
+func isExcluded(item resolvedMiddleware, excluded []string) bool {
+	for _, name := range item.names {
+		for _, entry := range excluded {
+			if name == entry {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AliasMiddleware registers a middleware with the name, so that it can be used by the name
+//
+//	router.AliasMiddleware("auth", auth.New())
+//	router.GET("/profile", profile).Middleware("auth")
+func (router *Router) AliasMiddleware(name string, handler middleware.IMiddleware) *Router {
+	router.middlewareAliases[name] = handler
+	return router
+}
+
+// MiddlewareGroup registers a group of middlewares with the name, middlewares can be names of aliases or groups
+//
+//	router.MiddlewareGroup("api", "throttle", reqid.Default())
+//	router.Group("api", routes).Middleware("api")
+func (router *Router) MiddlewareGroup(name string, middlewares ...any) *Router {
+	router.middlewareGroups[name] = newMiddlewareEntries(middlewares...)
+	return router
+}
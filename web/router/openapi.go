@@ -0,0 +1,47 @@
+package router
+
+import (
+	"sync"
+
+	"github.com/wardonne/gopi/web/context"
+	"github.com/wardonne/gopi/web/openapi"
+)
+
+// OpenAPI generates the OpenAPI document of all routes
+//
+//	document := router.OpenAPI(openapi.Info{Title: "API", Version: "1.0.0"})
+func (router *Router) OpenAPI(info openapi.Info) *openapi.Document {
+	routes := router.routes
+	if routes == nil {
+		routes = router.List()
+	}
+	items := make([]openapi.Route, 0, len(routes))
+	for _, route := range routes {
+		if route.Path() == router.openAPIPath {
+			continue
+		}
+		items = append(items, route)
+	}
+	return openapi.Generate(info, items...)
+}
+
+// ServeOpenAPI serves the OpenAPI document in json at the path, the document is generated on first request
+//
+//	router.ServeOpenAPI("/openapi.json", openapi.Info{Title: "API", Version: "1.0.0"})
+func (router *Router) ServeOpenAPI(path string, info openapi.Info) *RouteHandler {
+	var once sync.Once
+	var document *openapi.Document
+	route := router.GET(path, func(request *context.Request) context.IResponse {
+		once.Do(func() {
+			document = router.OpenAPI(info)
+		})
+		return context.NewResponse(200).JSON(document)
+	})
+	router.openAPIPath = route.Path()
+	return route
+}
+
+// DumpOpenAPI writes the OpenAPI document to the file, in yaml if the extension is .yaml or .yml, otherwise in json
+func (router *Router) DumpOpenAPI(filename string, info openapi.Info) error {
+	return router.OpenAPI(info).WriteFile(filename)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/wardonne/gopi/container"
 	"github.com/wardonne/gopi/pipeline"
 	"github.com/wardonne/gopi/validation"
 	"github.com/wardonne/gopi/web/binding"
@@ -16,6 +17,7 @@ import (
 type RouteAction struct {
 	Route
 	handler        string
+	binding        *handlerBinding
 	controller     IController
 	controllerType reflect.Type
 }
@@ -26,9 +28,28 @@ func (action *RouteAction) AS(name string) IRoute {
 	return action
 }
 
-// Use sets middlewares
+// Use appends middlewares to the route
 func (action *RouteAction) Use(middlewares ...middleware.IMiddleware) IRoute {
-	action.middlewares.AddAll(middlewares...)
+	for _, handler := range middlewares {
+		action.middlewares.use(middlewareEntry{handler: handler})
+	}
+	return action
+}
+
+// Middleware appends middleware aliases or groups registered on [Router] to the route
+func (action *RouteAction) Middleware(names ...string) IRoute {
+	for _, name := range names {
+		action.middlewares.use(middlewareEntry{name: name})
+	}
+	return action
+}
+
+// WithoutMiddleware excludes middlewares of groups from the route by the names of aliases or groups
+//
+//	router.AliasMiddleware("cors", cors.New(options))
+//	route.WithoutMiddleware("auth", "cors")
+func (action *RouteAction) WithoutMiddleware(names ...string) IRoute {
+	action.middlewares.without(names...)
 	return action
 }
 
@@ -43,6 +64,7 @@ func (action *RouteAction) Validate(form validation.IValidateForm, bindings ...b
 	}
 	form.SetEngine(action.router.validateEngine)
 	action.validation = validate.New(form, bindings...)
+	action.form, action.bindings = form, bindings
 	return action
 }
 
@@ -59,22 +81,26 @@ func (action *RouteAction) HandleRequest(request *context.Request) context.IResp
 	} else {
 		controllerValue = reflect.New(action.controllerType)
 	}
-	controllerValue.MethodByName("Init").Call([]reflect.Value{
-		reflect.ValueOf(request),
-	})
+	if c, ok := container.FromContext(request.Context()); ok {
+		if err := c.Inject(controllerValue.Interface()); err != nil {
+			panic(err)
+		}
+	}
 	pl := new(pipeline.Pipeline[*context.Request, context.IResponse])
-	pipes := make([]pipeline.IPipe[*context.Request, context.IResponse], 0, action.middlewares.Count())
-	action.middlewares.Range(func(middleware middleware.IMiddleware) bool {
+	middlewares := action.handlers()
+	pipes := make([]pipeline.IPipe[*context.Request, context.IResponse], 0, len(middlewares))
+	for _, middleware := range middlewares {
 		pipes = append(pipes, pipeline.AsPipe[*context.Request, context.IResponse](middleware))
-		return true
-	})
+	}
 	pl = pl.Send(request).Through(pipes...)
 	if action.HasValidation() {
 		pl = pl.AppendThroughCallback(action.validation)
 	}
 	return pl.Then(func(request *context.Request) context.IResponse {
-		outputs := controllerValue.MethodByName(action.handler).Call([]reflect.Value{})
-		resp := outputs[0].Interface().(context.IResponse)
-		return resp
+		// inits the controller with the request passed through middlewares, which may carry a new context
+		controllerValue.MethodByName("Init").Call([]reflect.Value{
+			reflect.ValueOf(request),
+		})
+		return action.binding.call(request, controllerValue.MethodByName(action.handler))
 	})
 }
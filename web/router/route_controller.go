@@ -5,7 +5,6 @@ import (
 	"reflect"
 	"strings"
 
-	"github.com/wardonne/gopi/support/collection/list"
 	"github.com/wardonne/gopi/web/context"
 	"github.com/wardonne/gopi/web/middleware"
 )
@@ -23,7 +22,7 @@ type RouteController struct {
 	Prefix             string
 	RouteGroups        []IRouteGroup
 	Routes             []*RouteAction
-	Middlewares        *list.ArrayList[middleware.IMiddleware]
+	middlewares        *middlewareStack
 	ControllerInstance IController
 	ControllerType     reflect.Type
 }
@@ -40,9 +39,23 @@ func (group *RouteController) List() []IRoute {
 	return routes
 }
 
-// Use sets middlewares to current group
+// Use appends middlewares to current group, they apply to actions of the group and its sub groups
 func (group *RouteController) Use(middlewares ...middleware.IMiddleware) {
-	group.Middlewares.AddAll(middlewares...)
+	for _, handler := range middlewares {
+		group.middlewares.use(middlewareEntry{handler: handler})
+	}
+}
+
+// Middleware appends middleware aliases or groups registered on [Router] to current group
+func (group *RouteController) Middleware(names ...string) {
+	for _, name := range names {
+		group.middlewares.use(middlewareEntry{name: name})
+	}
+}
+
+// WithoutMiddleware excludes middlewares from actions of the group by the names of aliases or groups
+func (group *RouteController) WithoutMiddleware(names ...string) {
+	group.middlewares.without(names...)
 }
 
 // Group registes a sub group of routes to current route
@@ -54,7 +67,7 @@ func (group *RouteController) Group(prefix string, callback func(group *RouteCon
 			strings.TrimLeft(prefix, "/"),
 		}, "/"),
 		Routes:             make([]*RouteAction, 0),
-		Middlewares:        group.Middlewares,
+		middlewares:        newMiddlewareStack(group.middlewares),
 		ControllerInstance: group.ControllerInstance,
 		ControllerType:     group.ControllerType,
 	}
@@ -72,7 +85,7 @@ func (group *RouteController) Controller(prefix string, controller IController,
 			strings.TrimLeft(prefix, "/"),
 		}, "/"),
 		Routes:             make([]*RouteAction, 0),
-		Middlewares:        group.Middlewares,
+		middlewares:        newMiddlewareStack(group.middlewares),
 		ControllerInstance: controller,
 		ControllerType:     reflect.TypeOf(controller),
 	}
@@ -83,9 +96,7 @@ func (group *RouteController) Controller(prefix string, controller IController,
 
 // Route registers controller's action to current route group and returns an instance of [RouteAction]
 //
-// the action should only receive only the receiver
-//
-// the action should only return an implemention of [context.IResponse]
+// params of the action are bound from the request, and its returns are rendered, see [RouteGroup.Route]
 //
 // # NOTICE: The handler CAN'T be an empty string
 //
@@ -96,6 +107,7 @@ func (group *RouteController) Controller(prefix string, controller IController,
 //	type Controller struct{}
 //
 //	func (c *Controller) Login() context.IResponse
+//	func (c *Controller) Show(id int) (*UserDTO, error)
 func (group *RouteController) Route(method, path, handler string) *RouteAction {
 	handler = strings.TrimSpace(handler)
 	if len(handler) == 0 {
@@ -109,18 +121,6 @@ func (group *RouteController) Route(method, path, handler string) *RouteAction {
 		panic(group.ControllerType.Name() + "." + handler + " not found")
 	}
 
-	if numIn := handlerType.Type.NumIn(); numIn != 1 {
-		panic("invalid number of input")
-	}
-
-	if numOut := handlerType.Type.NumOut(); numOut != 1 {
-		panic("invalid number of output")
-	}
-
-	if outputType := handlerType.Type.Out(0); !outputType.Implements(reflect.TypeOf((*context.IResponse)(nil)).Elem()) {
-		panic("invalid return type, should be an implemention of context.IResponse")
-	}
-
 	pathWithPrefix := strings.Join([]string{
 		strings.TrimRight(group.Prefix, "/"),
 		strings.TrimLeft(path, "/"),
@@ -128,18 +128,23 @@ func (group *RouteController) Route(method, path, handler string) *RouteAction {
 	if path == "" {
 		pathWithPrefix = pathWithPrefix[:len(pathWithPrefix)-1]
 	}
+	// the receiver is skipped
+	b := newHandlerBinding(group.router, handlerType.Type, 1, pathWithPrefix)
 	action := &RouteAction{
 		Route: Route{
 			router:      group.router,
 			name:        "",
 			method:      method,
 			path:        pathWithPrefix,
-			middlewares: group.Middlewares,
+			middlewares: newMiddlewareStack(group.middlewares),
 		},
 		handler:        handler,
+		binding:        b,
 		controller:     group.ControllerInstance,
 		controllerType: group.ControllerType,
 	}
+	// describes the form in the OpenAPI document
+	action.form = b.form()
 	group.Routes = append(group.Routes, action)
 	return action
 }
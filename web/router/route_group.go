@@ -5,7 +5,6 @@ import (
 	"reflect"
 	"strings"
 
-	"github.com/wardonne/gopi/support/collection/list"
 	"github.com/wardonne/gopi/web/middleware"
 )
 
@@ -16,7 +15,7 @@ type RouteGroup struct {
 	Prefix      string
 	RouteGroups []IRouteGroup
 	Routes      []*RouteHandler
-	Middlewares *list.ArrayList[middleware.IMiddleware]
+	middlewares *middlewareStack
 }
 
 // List lists all routes in current group
@@ -31,9 +30,25 @@ func (group *RouteGroup) List() []IRoute {
 	return routes
 }
 
-// Use sets middlewares to the group
+// Use appends middlewares to the group, they apply to routes of the group and its sub groups
 func (group *RouteGroup) Use(middlewares ...middleware.IMiddleware) {
-	group.Middlewares.AddAll(middlewares...)
+	for _, handler := range middlewares {
+		group.middlewares.use(middlewareEntry{handler: handler})
+	}
+}
+
+// Middleware appends middleware aliases or groups registered on [Router] to the group
+//
+//	router.Group("api", routes).Middleware("api", "auth")
+func (group *RouteGroup) Middleware(names ...string) {
+	for _, name := range names {
+		group.middlewares.use(middlewareEntry{name: name})
+	}
+}
+
+// WithoutMiddleware excludes middlewares from routes of the group by the names of aliases or groups
+func (group *RouteGroup) WithoutMiddleware(names ...string) {
+	group.middlewares.without(names...)
 }
 
 // Group registers sub route group and returns the sub group instance
@@ -46,7 +61,7 @@ func (group *RouteGroup) Group(prefix string, callback func(group *RouteGroup))
 		}, "/"),
 		RouteGroups: make([]IRouteGroup, 0),
 		Routes:      make([]*RouteHandler, 0),
-		Middlewares: group.Middlewares,
+		middlewares: newMiddlewareStack(group.middlewares),
 	}
 	group.RouteGroups = append(group.RouteGroups, routeGroup)
 	callback(routeGroup)
@@ -62,7 +77,7 @@ func (group *RouteGroup) Controller(prefix string, controller IController, callb
 			strings.TrimLeft(prefix, "/"),
 		}, "/"),
 		Routes:             make([]*RouteAction, 0),
-		Middlewares:        group.Middlewares,
+		middlewares:        newMiddlewareStack(group.middlewares),
 		ControllerInstance: controller,
 		ControllerType:     reflect.TypeOf(controller),
 	}
@@ -72,7 +87,20 @@ func (group *RouteGroup) Controller(prefix string, controller IController, callb
 }
 
 // Route registers a handler route to current group and it returns an instance of [RouteHandler]
-func (group *RouteGroup) Route(method, path string, handler Handler) *RouteHandler {
+//
+// the handler is a [Handler], or a function whose params are bound from the request,
+// it panics if the params or returns of the handler are not supported.
+//
+// scalar params are bound from path params by order, not by name,
+// bind path params by name with `param` tags of a form instead.
+//
+//	group.Route(http.MethodPut, "users/:id", func(id int, form *UpdateUserForm) (*UserDTO, error) {
+//		return users.Update(id, form)
+//	})
+//	group.Route(http.MethodGet, "users/:user/posts/:post", func(form *ShowPostForm) (*PostDTO, error) {
+//		return posts.Find(form.UserID, form.PostID) // UserID `param:"user"`, PostID `param:"post"`
+//	})
+func (group *RouteGroup) Route(method, path string, handler any) *RouteHandler {
 	pathWithPrefix := strings.Join([]string{
 		strings.TrimRight(group.Prefix, "/"),
 		strings.TrimLeft(path, "/"),
@@ -86,55 +114,56 @@ func (group *RouteGroup) Route(method, path string, handler Handler) *RouteHandl
 			name:        "",
 			method:      method,
 			path:        pathWithPrefix,
-			middlewares: group.Middlewares,
+			middlewares: newMiddlewareStack(group.middlewares),
 		},
-		handler: handler,
+		fn: handler,
 	}
+	route.bind(handler)
 	group.Routes = append(group.Routes, route)
 	return route
 }
 
 // HEAD registers a handler route with method [http.MethodHead]
-func (group *RouteGroup) HEAD(path string, handler Handler) *RouteHandler {
+func (group *RouteGroup) HEAD(path string, handler any) *RouteHandler {
 	return group.Route(http.MethodHead, path, handler)
 }
 
 // CONNECT registers a handler route with method [http.MethodConnect]
-func (group *RouteGroup) CONNECT(path string, handler Handler) *RouteHandler {
+func (group *RouteGroup) CONNECT(path string, handler any) *RouteHandler {
 	return group.Route(http.MethodConnect, path, handler)
 }
 
 // OPTIONS registers a handler route with method [http.MethodOptions]
-func (group *RouteGroup) OPTIONS(path string, handler Handler) *RouteHandler {
+func (group *RouteGroup) OPTIONS(path string, handler any) *RouteHandler {
 	return group.Route(http.MethodOptions, path, handler)
 }
 
 // TRACE registers a handler route with method [http.MethodTrace]
-func (group *RouteGroup) TRACE(path string, handler Handler) *RouteHandler {
+func (group *RouteGroup) TRACE(path string, handler any) *RouteHandler {
 	return group.Route(http.MethodTrace, path, handler)
 }
 
 // GET registers a handler route with method [http.MethodGet]
-func (group *RouteGroup) GET(path string, handler Handler) *RouteHandler {
+func (group *RouteGroup) GET(path string, handler any) *RouteHandler {
 	return group.Route(http.MethodGet, path, handler)
 }
 
 // POST registers a handler route with method [http.MethodPost]
-func (group *RouteGroup) POST(path string, handler Handler) *RouteHandler {
+func (group *RouteGroup) POST(path string, handler any) *RouteHandler {
 	return group.Route(http.MethodPost, path, handler)
 }
 
 // PUT registers a handler route with method [http.MethodPut]
-func (group *RouteGroup) PUT(path string, handler Handler) *RouteHandler {
+func (group *RouteGroup) PUT(path string, handler any) *RouteHandler {
 	return group.Route(http.MethodPut, path, handler)
 }
 
 // PATCH registers a handler route with method [http.MethodPatch]
-func (group *RouteGroup) PATCH(path string, handler Handler) *RouteHandler {
+func (group *RouteGroup) PATCH(path string, handler any) *RouteHandler {
 	return group.Route(http.MethodPatch, path, handler)
 }
 
 // DELETE registers a handler route with method [http.MethodDelete]
-func (group *RouteGroup) DELETE(path string, handler Handler) *RouteHandler {
+func (group *RouteGroup) DELETE(path string, handler any) *RouteHandler {
 	return group.Route(http.MethodDelete, path, handler)
 }
@@ -16,6 +16,30 @@ import (
 type RouteHandler struct {
 	Route
 	handler Handler
+	fn      any
+}
+
+// bind converts the function to [Handler], see [handlerBinding]
+func (route *RouteHandler) bind(fn any) {
+	if fn == nil {
+		panic("handler is nil")
+	}
+	if handler, ok := fn.(Handler); ok {
+		route.handler = handler
+		return
+	}
+	value := reflect.ValueOf(fn)
+	// named function types of Handler, e.g. `type Show func(*context.Request) context.IResponse`
+	if value.Kind() == reflect.Func && value.Type().ConvertibleTo(handlerType) {
+		route.handler = value.Convert(handlerType).Interface().(Handler)
+		return
+	}
+	b := newHandlerBinding(route.router, reflect.TypeOf(fn), 0, route.path)
+	route.handler = func(request *context.Request) context.IResponse {
+		return b.call(request, value)
+	}
+	// describes the form in the OpenAPI document
+	route.form = b.form()
 }
 
 // AS sets the name
@@ -24,9 +48,28 @@ func (route *RouteHandler) AS(name string) IRoute {
 	return route
 }
 
-// Use sets middlewares
+// Use appends middlewares to the route
 func (route *RouteHandler) Use(middlewares ...middleware.IMiddleware) IRoute {
-	route.middlewares.AddAll(middlewares...)
+	for _, handler := range middlewares {
+		route.middlewares.use(middlewareEntry{handler: handler})
+	}
+	return route
+}
+
+// Middleware appends middleware aliases or groups registered on [Router] to the route
+func (route *RouteHandler) Middleware(names ...string) IRoute {
+	for _, name := range names {
+		route.middlewares.use(middlewareEntry{name: name})
+	}
+	return route
+}
+
+// WithoutMiddleware excludes middlewares of groups from the route by the names of aliases or groups
+//
+//	router.AliasMiddleware("cors", cors.New(options))
+//	route.WithoutMiddleware("auth", "cors")
+func (route *RouteHandler) WithoutMiddleware(names ...string) IRoute {
+	route.middlewares.without(names...)
 	return route
 }
 
@@ -41,22 +84,22 @@ func (route *RouteHandler) Validate(form validation.IValidateForm, bindings ...b
 	}
 	form.SetEngine(route.router.validateEngine)
 	route.validation = validate.New(form, bindings...)
+	route.form, route.bindings = form, bindings
 	return route
 }
 
 // Handler returns the handler's name
 func (route *RouteHandler) Handler() string {
-	return runtime.FuncForPC(reflect.ValueOf(route.handler).Pointer()).Name()
+	return runtime.FuncForPC(reflect.ValueOf(route.fn).Pointer()).Name()
 }
 
 // HandleRequest handles the http request
 func (route *RouteHandler) HandleRequest(request *context.Request) context.IResponse {
 	pl := new(pipeline.Pipeline[*context.Request, context.IResponse])
 	pl = pl.Send(request)
-	route.middlewares.Range(func(middleware middleware.IMiddleware) bool {
-		pl.AppendThroughCallback(middleware)
-		return true
-	})
+	for _, middleware := range route.handlers() {
+		pl = pl.AppendThroughCallback(middleware)
+	}
 	if route.HasValidation() {
 		pl = pl.AppendThroughCallback(route.validation)
 	}
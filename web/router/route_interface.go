@@ -1,7 +1,8 @@
 package router
 
 import (
-	"github.com/wardonne/gopi/support/collection/list"
+	"sync"
+
 	"github.com/wardonne/gopi/validation"
 	"github.com/wardonne/gopi/web/binding"
 	"github.com/wardonne/gopi/web/context"
@@ -12,6 +13,8 @@ import (
 type IRoute interface {
 	AS(name string) IRoute
 	Use(middlewares ...middleware.IMiddleware) IRoute
+	Middleware(names ...string) IRoute
+	WithoutMiddleware(names ...string) IRoute
 	Validate(form validation.IValidateForm, bindings ...binding.Binding) IRoute
 	Name() string
 	Method() string
@@ -19,6 +22,7 @@ type IRoute interface {
 	Middlewares() []middleware.IMiddleware
 	Handler() string
 	HasValidation() bool
+	Form() (validation.IValidateForm, []binding.Binding)
 	HandleRequest(request *context.Request) context.IResponse
 }
 
@@ -29,8 +33,12 @@ type Route struct {
 	name        string
 	method      string
 	path        string
-	middlewares *list.ArrayList[middleware.IMiddleware]
+	middlewares *middlewareStack
+	resolveOnce sync.Once
+	resolved    []middleware.IMiddleware
 	validation  middleware.IMiddleware
+	form        validation.IValidateForm
+	bindings    []binding.Binding
 }
 
 // Name returns the name of route
@@ -48,12 +56,25 @@ func (route *Route) Path() string {
 	return route.path
 }
 
-// Middlewares returns the middlewares of the route
+// Middlewares returns the middlewares of the route, ordered parent group -> child group -> route
 func (route *Route) Middlewares() []middleware.IMiddleware {
-	return route.middlewares.ToArray()
+	return route.middlewares.resolve(route.router)
+}
+
+// handlers returns the middlewares of the route, they are resolved once on first call
+func (route *Route) handlers() []middleware.IMiddleware {
+	route.resolveOnce.Do(func() {
+		route.resolved = route.Middlewares()
+	})
+	return route.resolved
 }
 
 // HasValidation returns whether the route has a binded validation
 func (route *Route) HasValidation() bool {
 	return route.validation != nil
 }
+
+// Form returns the validation form and bindings binded to the route
+func (route *Route) Form() (validation.IValidateForm, []binding.Binding) {
+	return route.form, route.bindings
+}
@@ -6,10 +6,11 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/wardonne/gopi/container"
 	"github.com/wardonne/gopi/contract"
-	"github.com/wardonne/gopi/support/collection/list"
 	"github.com/wardonne/gopi/validation"
 	"github.com/wardonne/gopi/web/context"
 	"github.com/wardonne/gopi/web/middleware"
@@ -25,6 +26,17 @@ type Router struct {
 	routes         []IRoute
 	validateEngine validation.Engine
 	corsOptions    *cors.CORSOptions
+	serverOptions  *ServerOptions
+	signingKey     []byte
+	openAPIPath    string
+	container      *container.Container
+	errorHandler   contract.ErrorHandler
+
+	middlewareAliases map[string]middleware.IMiddleware
+	middlewareGroups  map[string][]middlewareEntry
+	server            *http.Server
+	serverMu          sync.Mutex
+	compileOnce       sync.Once
 }
 
 // New creates a new [Router] instance
@@ -32,11 +44,13 @@ func New() *Router {
 	router := &Router{
 		RouteGroup: &RouteGroup{
 			Prefix:      "/",
-			Middlewares: list.NewArrayList[middleware.IMiddleware](),
+			middlewares: newMiddlewareStack(nil),
 			RouteGroups: make([]IRouteGroup, 0),
 			Routes:      make([]*RouteHandler, 0),
 		},
-		HTTPRouter: httprouter.New(),
+		HTTPRouter:        httprouter.New(),
+		middlewareAliases: make(map[string]middleware.IMiddleware),
+		middlewareGroups:  make(map[string][]middlewareEntry),
 	}
 	router.router = router
 	router.HTTPRouter.PanicHandler = defaultErrorHandler
@@ -55,8 +69,22 @@ func (router *Router) SetValidateEngine(ve validation.Engine) *Router {
 	return router
 }
 
-// SetErrorHandler sets custom error handler
+// SetContainer sets the dependency injection container
+//
+// every request is served with a scope of the container, fields of controllers tagged with `inject` are injected from it.
+func (router *Router) SetContainer(c *container.Container) *Router {
+	router.container = c
+	return router
+}
+
+// Container returns the dependency injection container
+func (router *Router) Container() *container.Container {
+	return router.container
+}
+
+// SetErrorHandler sets custom error handler, it renders panics and errors returned by handlers
 func (router *Router) SetErrorHandler(handler contract.ErrorHandler) *Router {
+	router.errorHandler = handler
 	router.HTTPRouter.PanicHandler = func(w http.ResponseWriter, r *http.Request, i interface{}) {
 		switch v := i.(type) {
 		case error:
@@ -80,19 +108,35 @@ func (router *Router) Registe(register Register) {
 	register(router)
 }
 
-// Run starts the http server
+// Compile registers all routes into [Router.HTTPRouter]
 //
-// # NOTICE: should call Prepare first before calling Run
-func (router *Router) Run(addr string) error {
+// routes are compiled only once, routes registered after compiling are ignored.
+// [Router.Handler], [Router.ServeHTTP] and the serving methods compile routes automatically.
+func (router *Router) Compile() *Router {
+	router.compileOnce.Do(router.compile)
+	return router
+}
+
+func (router *Router) compile() {
 	if router.routes == nil {
 		router.routes = router.List()
 	}
 	for _, route := range router.routes {
+		if r, ok := route.(interface {
+			handlers() []middleware.IMiddleware
+		}); ok {
+			// resolves middlewares before serving, so that unknown middleware names panic early
+			r.handlers()
+		}
 		router.HTTPRouter.Handle(route.Method(), route.Path(), func(route IRoute) httprouter.Handle {
 			return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 				ctx := r.Context()
 				ctx = libctx.WithValue(ctx, httprouter.ParamsKey, p)
-				request := context.NewRequest(r, p)
+				if router.container != nil {
+					ctx = container.NewContext(ctx, router.container.Scope())
+				}
+				request := context.NewRequest(r.WithContext(ctx), p)
+				request.SetURLGenerator(router)
 				resp := route.HandleRequest(request)
 				resp.Send(w, r)
 			}
@@ -121,5 +165,17 @@ func (router *Router) Run(addr string) error {
 			w.WriteHeader(http.StatusNoContent)
 		})
 	}
-	return http.ListenAndServe(addr, router.HTTPRouter)
+}
+
+// Handler compiles routes and returns the router as [http.Handler],
+// so that it can be mounted in another server or tested with httptest
+//
+//	server := httptest.NewServer(router.Handler())
+func (router *Router) Handler() http.Handler {
+	return router.Compile().HTTPRouter
+}
+
+// ServeHTTP implements [http.Handler]
+func (router *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	router.Handler().ServeHTTP(w, r)
 }
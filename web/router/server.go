@@ -0,0 +1,102 @@
+package router
+
+import (
+	libctx "context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ServerOptions options of the http server owned by [Router]
+type ServerOptions struct {
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+}
+
+// DefaultServerOptions returns the default server options
+func DefaultServerOptions() *ServerOptions {
+	return &ServerOptions{
+		ReadTimeout:       30 * time.Second,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		MaxHeaderBytes:    http.DefaultMaxHeaderBytes,
+	}
+}
+
+// SetServerOptions sets the options of the http server, it should be called before serving
+func (router *Router) SetServerOptions(options *ServerOptions) *Router {
+	router.serverOptions = options
+	return router
+}
+
+// Server returns the http server owned by the router, it's created on first call
+func (router *Router) Server() *http.Server {
+	router.serverMu.Lock()
+	defer router.serverMu.Unlock()
+	if router.server == nil {
+		options := router.serverOptions
+		if options == nil {
+			options = DefaultServerOptions()
+		}
+		router.server = &http.Server{
+			Handler:           router.Handler(),
+			ReadTimeout:       options.ReadTimeout,
+			ReadHeaderTimeout: options.ReadHeaderTimeout,
+			WriteTimeout:      options.WriteTimeout,
+			IdleTimeout:       options.IdleTimeout,
+			MaxHeaderBytes:    options.MaxHeaderBytes,
+		}
+	}
+	return router.server
+}
+
+// Run starts the http server listening on addr
+//
+// it blocks until the server is stopped, and returns nil if stopped by [Router.Shutdown]
+//
+//	router.Run(":8080")
+func (router *Router) Run(addr string) error {
+	server := router.Server()
+	server.Addr = addr
+	return serveErr(server.ListenAndServe())
+}
+
+// RunTLS starts the https server listening on addr
+//
+//	router.RunTLS(":8443", "server.crt", "server.key")
+func (router *Router) RunTLS(addr, certFile, keyFile string) error {
+	server := router.Server()
+	server.Addr = addr
+	return serveErr(server.ListenAndServeTLS(certFile, keyFile))
+}
+
+// Serve accepts connections on the listener
+//
+//	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+//	go router.Serve(listener)
+func (router *Router) Serve(listener net.Listener) error {
+	return serveErr(router.Server().Serve(listener))
+}
+
+// Shutdown gracefully shuts down the http server, it waits for active connections until ctx is done
+func (router *Router) Shutdown(ctx libctx.Context) error {
+	router.serverMu.Lock()
+	server := router.server
+	router.serverMu.Unlock()
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
+
+func serveErr(err error) error {
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wardonne/gopi/web/middleware"
+	"github.com/wardonne/gopi/web/middleware/signed"
+)
+
+var (
+	// ErrRouteNotFound no route has the given name
+	ErrRouteNotFound = errors.New("route not found")
+	// ErrMissingRouteParam a param of the route pattern is not provided
+	ErrMissingRouteParam = errors.New("missing route param")
+	// ErrSigningKeyEmpty signing key is not set
+	ErrSigningKeyEmpty = errors.New("signing key is empty, please call SetSigningKey to set it first")
+)
+
+// SetSigningKey sets the key used to sign and verify urls
+func (router *Router) SetSigningKey(key []byte) *Router {
+	router.signingKey = key
+	return router
+}
+
+// NamedRoute returns the route with the name
+func (router *Router) NamedRoute(name string) (IRoute, bool) {
+	routes := router.routes
+	if routes == nil {
+		routes = router.List()
+	}
+	for _, route := range routes {
+		if route.Name() == name {
+			return route, true
+		}
+	}
+	return nil, false
+}
+
+// URL builds the path of the named route, params not in the route pattern are ignored
+//
+//	router.GET("/users/:id", show).AS("users.show")
+//	router.URL("users.show", map[string]any{"id": 1}, url.Values{"tab": {"posts"}}) // /users/1?tab=posts
+func (router *Router) URL(name string, params map[string]any, query url.Values) (string, error) {
+	route, ok := router.NamedRoute(name)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrRouteNotFound, name)
+	}
+	path, err := BuildPath(route.Path(), params)
+	if err != nil {
+		return "", err
+	}
+	if len(query) == 0 {
+		return path, nil
+	}
+	return path + "?" + query.Encode(), nil
+}
+
+// SignedURL builds the path of the named route with a signature, it never expires if expires is zero
+//
+//	router.SignedURL("files.download", map[string]any{"id": 1}, nil, time.Now().Add(time.Hour))
+func (router *Router) SignedURL(name string, params map[string]any, query url.Values, expires time.Time) (string, error) {
+	if len(router.signingKey) == 0 {
+		return "", ErrSigningKeyEmpty
+	}
+	path, err := router.URL(name, params, nil)
+	if err != nil {
+		return "", err
+	}
+	return path + "?" + signed.Sign(path, query, router.signingKey, expires).Encode(), nil
+}
+
+// SignedMiddleware returns a middleware which verifies the signed urls
+//
+//	router.GET("/files/:id", download).AS("files.download").Use(router.SignedMiddleware())
+func (router *Router) SignedMiddleware() middleware.IMiddleware {
+	if len(router.signingKey) == 0 {
+		panic(ErrSigningKeyEmpty)
+	}
+	return signed.New(router.signingKey)
+}
+
+// BuildPath replaces the named params (`:id`) and catch-all params (`*path`) of the pattern
+//
+//	BuildPath("/users/:id", map[string]any{"id": 1}) // /users/1
+//	BuildPath("/files/*path", map[string]any{"path": "a b/c"}) // /files/a%20b/c
+func BuildPath(pattern string, params map[string]any) (string, error) {
+	segments := strings.Split(pattern, "/")
+	for idx, segment := range segments {
+		if len(segment) < 2 || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		value, ok := params[segment[1:]]
+		if !ok || value == nil {
+			return "", fmt.Errorf("%w: %s", ErrMissingRouteParam, segment[1:])
+		}
+		str := fmt.Sprint(value)
+		if segment[0] == ':' {
+			segments[idx] = url.PathEscape(str)
+			continue
+		}
+		parts := strings.Split(strings.TrimPrefix(str, "/"), "/")
+		for i, part := range parts {
+			parts[i] = url.PathEscape(part)
+		}
+		segments[idx] = strings.Join(parts, "/")
+	}
+	return strings.Join(segments, "/"), nil
+}
@@ -0,0 +1,68 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/container"
+	"github.com/wardonne/gopi/web"
+	"github.com/wardonne/gopi/web/context"
+)
+
+type counter struct {
+	count int
+}
+
+type injectcontroller struct {
+	web.Controller
+	First  *counter `inject:""`
+	Second *counter `inject:""`
+}
+
+func (c *injectcontroller) Show() context.IResponse {
+	c.First.count++
+	c.Second.count++
+	return context.NewResponse(200, c.First.count)
+}
+
+func TestRouter_SetContainer(t *testing.T) {
+	t.Run("controllers are injected per request", func(t *testing.T) {
+		c := container.New()
+		container.Scoped[*counter](c, func() *counter { return new(counter) })
+		r := New().SetContainer(c)
+		r.Controller("/counter", new(injectcontroller), func(group *RouteController) {
+			group.GET("show", "Show")
+		})
+		for i := 0; i < 2; i++ {
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/counter/show", nil))
+			assert.Equal(t, 200, recorder.Code)
+			assert.Equal(t, "2", recorder.Body.String())
+		}
+	})
+
+	t.Run("scope is carried by request context", func(t *testing.T) {
+		c := container.New()
+		r := New().SetContainer(c)
+		assert.Same(t, c, r.Container())
+		r.GET("/", func(request *context.Request) context.IResponse {
+			_, ok := container.FromContext(request.Context())
+			return context.NewResponse(200, ok)
+		})
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, "true", recorder.Body.String())
+	})
+
+	t.Run("unbound dependencies fail the request", func(t *testing.T) {
+		r := New().SetContainer(container.New())
+		r.Controller("/counter", new(injectcontroller), func(group *RouteController) {
+			group.GET("show", "Show")
+		})
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/counter/show", nil))
+		assert.Equal(t, 500, recorder.Code)
+	})
+}
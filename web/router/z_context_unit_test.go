@@ -0,0 +1,103 @@
+package router
+
+import (
+	libctx "context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/pipeline"
+	"github.com/wardonne/gopi/web"
+	"github.com/wardonne/gopi/web/context"
+	"github.com/wardonne/gopi/web/middleware/timeout"
+)
+
+type contextcontroller struct {
+	web.Controller
+}
+
+func (c *contextcontroller) Deadline() context.IResponse {
+	if _, ok := c.Context().Deadline(); !ok {
+		return context.NewResponse(500, "no deadline")
+	}
+	return context.NewResponse(200, c.Context().Value(context.ContextKey("user")))
+}
+
+// lookup simulates a downstream library which only knows context.Context
+func lookup(ctx libctx.Context, key string) any {
+	return ctx.Value(context.ContextKey(key))
+}
+
+func TestRequest_Context(t *testing.T) {
+	t.Run("values are visible through context", func(t *testing.T) {
+		r := New()
+		r.GET("/", func(request *context.Request) context.IResponse {
+			request.Set("user", "john")
+			return context.NewResponse(200, lookup(request.Context(), "user"))
+		})
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, "john", recorder.Body.String())
+	})
+
+	t.Run("deadline is propagated to controllers", func(t *testing.T) {
+		r := New()
+		r.Use(timeout.New(time.Minute))
+		r.Use(func(request *context.Request, next pipeline.Next[*context.Request, context.IResponse]) context.IResponse {
+			request.Set("user", "john")
+			return next(request)
+		})
+		r.Controller("/", new(contextcontroller), func(group *RouteController) {
+			group.GET("deadline", "Deadline")
+		})
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/deadline", nil))
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "john", recorder.Body.String())
+	})
+
+	t.Run("cancellation is propagated to handlers and outgoing requests", func(t *testing.T) {
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer upstream.Close()
+
+		r := New()
+		errs := make(chan error, 1)
+		r.GET("/", func(request *context.Request) context.IResponse {
+			req, err := request.NewOutgoingRequest(http.MethodGet, upstream.URL, nil)
+			if err != nil {
+				errs <- err
+				return context.NewResponse(500)
+			}
+			_, err = http.DefaultClient.Do(req)
+			errs <- err
+			return context.NewResponse(200)
+		})
+		ctx, cancel := libctx.WithCancel(libctx.Background())
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			cancel()
+		}()
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+		assert.ErrorIs(t, <-errs, libctx.Canceled)
+	})
+
+	t.Run("WithContext keeps values", func(t *testing.T) {
+		request := context.NewRequest(httptest.NewRequest(http.MethodGet, "/", nil), nil)
+		request.Set("user", "john")
+		ctx, cancel := libctx.WithCancel(request.Context())
+		cancel()
+		clone := request.WithContext(ctx)
+		assert.Equal(t, "john", lookup(clone.Context(), "user"))
+		assert.Equal(t, "john", clone.Context().Value("user"))
+		assert.ErrorIs(t, clone.Context().Err(), libctx.Canceled)
+		assert.Nil(t, request.Context().Err())
+
+		clone.Set("role", "admin")
+		assert.Equal(t, "admin", lookup(request.Context(), "role"))
+		assert.Equal(t, "admin", lookup(context.NewRequest(httptest.NewRequest(http.MethodGet, "/", nil).WithContext(clone.Context()), nil).Context(), "role"))
+	})
+}
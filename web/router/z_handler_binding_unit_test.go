@@ -0,0 +1,255 @@
+package router
+
+import (
+	libctx "context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/container"
+	"github.com/wardonne/gopi/validation"
+	"github.com/wardonne/gopi/web"
+	"github.com/wardonne/gopi/web/context"
+)
+
+type userform struct {
+	validation.Form
+	ID   int    `param:"id" json:"-"`
+	Name string `json:"name"`
+}
+
+type userdto struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type userengine struct{}
+
+func (e *userengine) Struct(data any) error {
+	if data.(*userform).Name == "" {
+		return validator.ValidationErrors{testfilederror{}}
+	}
+	return nil
+}
+
+func (e *userengine) Translator(locale string) ut.Translator {
+	return nil
+}
+
+type statuserror struct{}
+
+func (statuserror) Error() string   { return "teapot" }
+func (statuserror) StatusCode() int { return http.StatusTeapot }
+
+type usercontroller struct {
+	web.Controller
+}
+
+func (c *usercontroller) Show(id uint, slug string) (*userdto, error) {
+	return &userdto{ID: int(id), Name: slug}, nil
+}
+
+func (c *usercontroller) Delete(id int) error {
+	return nil
+}
+
+type bindingerrorhandler struct{}
+
+func (bindingerrorhandler) Render(r *http.Request, err error) context.IResponse {
+	return context.NewResponse(499, "handled: "+err.Error())
+}
+
+type errorhandlerfunc func(r *http.Request, err error) context.IResponse
+
+func (fn errorhandlerfunc) Render(r *http.Request, err error) context.IResponse {
+	return fn(r, err)
+}
+
+func call(r http.Handler, method, target, body string, headers ...string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	for i := 0; i+1 < len(headers); i += 2 {
+		request.Header.Set(headers[i], headers[i+1])
+	}
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, request)
+	return recorder
+}
+
+type namedhandler func(*context.Request) context.IResponse
+
+func TestRouter_HandlerBinding(t *testing.T) {
+	r := New().SetValidateEngine(new(userengine))
+	r.GET("/users/:id/posts/:post", func(ctx *context.Request, id int, post uuid.UUID) (userdto, error) {
+		return userdto{ID: id, Name: post.String() + ctx.Query("suffix").String()}, nil
+	})
+	r.PUT("/users/:id", func(ctx libctx.Context, form *userform) (*userdto, error) {
+		return &userdto{ID: form.ID, Name: form.Name}, nil
+	})
+	r.GET("/errors/:kind", func(kind string) (context.IResponse, error) {
+		switch kind {
+		case "status":
+			return nil, statuserror{}
+		case "plain":
+			return nil, errors.New("secret")
+		default:
+			return context.NewResponse(201, "created"), nil
+		}
+	})
+	r.DELETE("/users/:id", func(id int) error { return nil })
+
+	t.Run("handlers are not bound by reflection", func(t *testing.T) {
+		r := New()
+		plain := func(request *context.Request) context.IResponse {
+			return context.NewResponse(200, "plain")
+		}
+		named := namedhandler(func(request *context.Request) context.IResponse {
+			return context.NewResponse(200, "named")
+		})
+		assert.Equal(t, reflect.ValueOf(plain).Pointer(), reflect.ValueOf(r.GET("/plain", plain).handler).Pointer())
+		assert.Equal(t, reflect.ValueOf(named).Pointer(), reflect.ValueOf(r.GET("/named", named).handler).Pointer())
+		assert.Equal(t, "named", call(r, http.MethodGet, "/named", "").Body.String())
+	})
+
+	t.Run("path params by order", func(t *testing.T) {
+		post := uuid.New()
+		recorder := call(r, http.MethodGet, "/users/3/posts/"+post.String()+"?suffix=!", "")
+		assert.Equal(t, 200, recorder.Code)
+		assert.JSONEq(t, `{"id":3,"name":"`+post.String()+`!"}`, recorder.Body.String())
+
+		recorder = call(r, http.MethodGet, "/users/x/posts/"+post.String(), "")
+		assert.Equal(t, 400, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `\"x\" is not a valid int`)
+
+		recorder = call(r, http.MethodGet, "/users/1/posts/invalid", "")
+		assert.Equal(t, 400, recorder.Code)
+	})
+
+	t.Run("form bound and validated", func(t *testing.T) {
+		recorder := call(r, http.MethodPut, "/users/5", `{"name":"john"}`, "Content-Type", context.MIMEJSON)
+		assert.Equal(t, 200, recorder.Code)
+		assert.JSONEq(t, `{"id":5,"name":"john"}`, recorder.Body.String())
+
+		recorder = call(r, http.MethodPut, "/users/5", `{}`, "Content-Type", context.MIMEJSON)
+		assert.Equal(t, 422, recorder.Code)
+		assert.JSONEq(t, `{"message":"the given data was invalid","errors":{"Name":["field required"]}}`, recorder.Body.String())
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		recorder := call(r, http.MethodPut, "/users/5", `{"name":`, "Content-Type", context.MIMEJSON)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.NotContains(t, recorder.Body.String(), http.StatusText(http.StatusInternalServerError))
+
+		recorder = call(r, http.MethodPut, "/users/5", `<userform><name>`, "Content-Type", context.MIMEXML)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		var bindErr *BindError
+		r := New().SetValidateEngine(new(userengine)).SetErrorHandler(errorhandlerfunc(func(r *http.Request, err error) context.IResponse {
+			assert.ErrorAs(t, err, &bindErr)
+			return context.NewResponse(http.StatusBadRequest)
+		}))
+		r.PUT("/users/:id", func(form *userform) {})
+		call(r, http.MethodPut, "/users/5", `{"name":`, "Content-Type", context.MIMEJSON)
+		assert.NotNil(t, bindErr)
+	})
+
+	t.Run("negotiation", func(t *testing.T) {
+		recorder := call(r, http.MethodPut, "/users/5", `{"name":"john"}`, "Content-Type", context.MIMEJSON, "Accept", context.MIMEXML)
+		assert.Equal(t, context.MIMEXML, recorder.Header().Get("Content-Type"))
+		assert.Contains(t, recorder.Body.String(), "<userdto>")
+	})
+
+	t.Run("errors in xml", func(t *testing.T) {
+		recorder := call(r, http.MethodGet, "/users/abc/posts/1", "", "Accept", context.MIMEXML)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, context.MIMEXML, recorder.Header().Get("Content-Type"))
+		assert.Contains(t, recorder.Body.String(), "<error><message>invalid path param")
+
+		recorder = call(r, http.MethodPut, "/users/5", `{}`, "Content-Type", context.MIMEJSON, "Accept", context.MIMEXML)
+		assert.Equal(t, 422, recorder.Code)
+		assert.Equal(t, `<error><message>the given data was invalid</message><errors><field name="Name"><message>field required</message></field></errors></error>`, recorder.Body.String())
+	})
+
+	t.Run("returns", func(t *testing.T) {
+		recorder := call(r, http.MethodGet, "/errors/status", "")
+		assert.Equal(t, http.StatusTeapot, recorder.Code)
+		assert.JSONEq(t, `{"message":"teapot"}`, recorder.Body.String())
+
+		recorder = call(r, http.MethodGet, "/errors/plain", "")
+		assert.Equal(t, 500, recorder.Code)
+		assert.NotContains(t, recorder.Body.String(), "secret")
+
+		recorder = call(r, http.MethodGet, "/errors/response", "")
+		assert.Equal(t, 201, recorder.Code)
+		assert.Equal(t, "created", recorder.Body.String())
+
+		recorder = call(r, http.MethodDelete, "/users/1", "")
+		assert.Equal(t, 204, recorder.Code)
+	})
+
+	t.Run("error handler", func(t *testing.T) {
+		r := New().SetErrorHandler(bindingerrorhandler{})
+		r.GET("/", func() (string, error) { return "", errors.New("failed") })
+		recorder := call(r, http.MethodGet, "/", "")
+		assert.Equal(t, 499, recorder.Code)
+		assert.Equal(t, "handled: failed", recorder.Body.String())
+	})
+
+	t.Run("services from container", func(t *testing.T) {
+		c := container.New()
+		container.Instance(c, &userdto{Name: "service"})
+		r := New().SetContainer(c)
+		r.GET("/", func(dto *userdto) string { return dto.Name })
+		assert.Equal(t, `"service"`, call(r, http.MethodGet, "/", "").Body.String())
+	})
+
+	t.Run("services without container", func(t *testing.T) {
+		r := New()
+		r.GET("/", func(dto *userdto) string { return dto.Name })
+		recorder := call(r, http.MethodGet, "/", "")
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.JSONEq(t, `{"message":"Internal Server Error"}`, recorder.Body.String())
+	})
+
+	t.Run("controller actions", func(t *testing.T) {
+		r := New()
+		r.Controller("/users", new(usercontroller), func(group *RouteController) {
+			group.GET(":id/:slug", "Show")
+			group.DELETE(":id", "Delete")
+		})
+		recorder := call(r, http.MethodGet, "/users/7/john", "")
+		assert.JSONEq(t, `{"id":7,"name":"john"}`, recorder.Body.String())
+		assert.Equal(t, 204, call(r, http.MethodDelete, "/users/7", "").Code)
+	})
+}
+
+func TestRouter_HandlerBindingValidation(t *testing.T) {
+	r := New()
+	assert.Panics(t, func() { r.GET("/", "not a function") })
+	assert.Panics(t, func() { r.GET("/", nil) })
+	assert.Panics(t, func() { r.GET("/:id", func(id, other int) {}) })
+	assert.Panics(t, func() { r.GET("/", func(m map[string]any) {}) })
+	assert.Panics(t, func() { r.GET("/", func(args ...int) {}) })
+	assert.Panics(t, func() { r.GET("/", func() (int, string) { return 0, "" }) })
+	assert.Panics(t, func() { r.GET("/", func() (int, string, error) { return 0, "", nil }) })
+	assert.PanicsWithValue(t, ErrValidateEngineEmpty, func() { r.GET("/", func(form *userform) {}) })
+	assert.Panics(t, func() {
+		r.Controller("/", new(usercontroller), func(group *RouteController) {
+			group.GET("", "Show")
+		})
+	})
+	assert.NotPanics(t, func() { r.GET("/:id", func(ctx *context.Request, id int) {}) })
+
+	t.Run("form is described in OpenAPI", func(t *testing.T) {
+		r := New().SetValidateEngine(new(userengine))
+		route := r.PUT("/users/:id", func(form *userform) {})
+		form, _ := route.Form()
+		assert.IsType(t, new(userform), form)
+	})
+}
@@ -0,0 +1,106 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/pipeline"
+	"github.com/wardonne/gopi/web/context"
+	"github.com/wardonne/gopi/web/middleware"
+)
+
+// trace creates a middleware which appends the name to the trace header of the response
+func trace(name string) middleware.IMiddleware {
+	return func(request *context.Request, next pipeline.Next[*context.Request, context.IResponse]) context.IResponse {
+		request.Set("trace", *request.GetString("trace", "")+name+",")
+		return next(request)
+	}
+}
+
+func traceHandler(request *context.Request) context.IResponse {
+	return context.NewResponse(200, strings.TrimSuffix(*request.GetString("trace", ""), ","))
+}
+
+func serve(r *Router, path string) string {
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder.Body.String()
+}
+
+func TestRouter_MiddlewareIsolation(t *testing.T) {
+	r := New()
+	r.Use(trace("root"))
+	r.Group("api", func(group *RouteGroup) {
+		group.Use(trace("api"))
+		group.GET("index", traceHandler)
+		group.Group("v1", func(group *RouteGroup) {
+			group.Use(trace("v1"))
+			group.GET("users", traceHandler).Use(trace("route"))
+		})
+		group.Group("v2", func(group *RouteGroup) {
+			group.GET("users", traceHandler)
+		})
+	})
+	r.Controller("web", new(testcontroller), func(group *RouteController) {
+		group.Use(trace("web"))
+	})
+	r.GET("/", traceHandler)
+
+	assert.Equal(t, "root", serve(r, "/"))
+	assert.Equal(t, "root,api", serve(r, "/api/index"))
+	assert.Equal(t, "root,api,v1,route", serve(r, "/api/v1/users"))
+	assert.Equal(t, "root,api", serve(r, "/api/v2/users"))
+	assert.Len(t, r.middlewares.entries, 1)
+}
+
+func TestRouter_WithoutMiddleware(t *testing.T) {
+	r := New()
+	r.AliasMiddleware("auth", trace("auth")).
+		AliasMiddleware("session", trace("session")).
+		AliasMiddleware("guest", trace("guest"))
+	r.Use(trace("root"))
+	r.Group("api", func(group *RouteGroup) {
+		group.Middleware("session")
+		group.Group("v1", func(group *RouteGroup) {
+			group.Middleware("auth", "guest")
+			group.GET("private", traceHandler)
+			group.GET("login", traceHandler).WithoutMiddleware("auth")
+			group.GET("public", traceHandler).WithoutMiddleware("auth", "session")
+		})
+	})
+
+	assert.Equal(t, "root,session,auth,guest", serve(r, "/api/v1/private"))
+	assert.Equal(t, "root,session,guest", serve(r, "/api/v1/login"))
+	assert.Equal(t, "root,guest", serve(r, "/api/v1/public"))
+}
+
+func TestRouter_MiddlewareGroup(t *testing.T) {
+	r := New()
+	r.AliasMiddleware("auth", trace("auth")).
+		AliasMiddleware("throttle", trace("throttle")).
+		MiddlewareGroup("api", "throttle", trace("json")).
+		MiddlewareGroup("private", "api", "auth")
+
+	r.Group("api", func(group *RouteGroup) {
+		group.GET("public", traceHandler).Middleware("api")
+		group.GET("private", traceHandler).Middleware("private")
+		group.GET("unthrottled", traceHandler).Middleware("private").WithoutMiddleware("throttle")
+		group.GET("public-only", traceHandler).Middleware("private").WithoutMiddleware("api")
+	})
+
+	assert.Equal(t, "throttle,json", serve(r, "/api/public"))
+	assert.Equal(t, "throttle,json,auth", serve(r, "/api/private"))
+	assert.Equal(t, "json,auth", serve(r, "/api/unthrottled"))
+	assert.Equal(t, "auth", serve(r, "/api/public-only"))
+
+	t.Run("unknown middleware", func(t *testing.T) {
+		r := New()
+		r.GET("/", traceHandler).Middleware("missing")
+		assert.PanicsWithError(t, ErrMiddlewareNotFound.Error()+": missing", func() {
+			r.Compile()
+		})
+	})
+}
@@ -0,0 +1,121 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/web"
+	"github.com/wardonne/gopi/web/context"
+	"github.com/wardonne/gopi/web/renderer/csv"
+)
+
+type negotiationcontroller struct {
+	web.Controller
+}
+
+func (c *negotiationcontroller) Index() context.IResponse {
+	return c.Negotiate(http.StatusOK, userdto{ID: 1, Name: "john"})
+}
+
+func TestNegotiate(t *testing.T) {
+	offers := []string{context.MIMEJSON, context.MIMEXML, "text/xml", context.MIMEYAML}
+	cases := map[string]string{
+		"":                                  context.MIMEJSON,
+		"*/*":                               context.MIMEJSON,
+		"application/xml":                   context.MIMEXML,
+		"application/xml, application/json": context.MIMEXML,
+		"application/xml;q=0.9, */*;q=0.1":  context.MIMEXML,
+		"application/xml;q=0.5, application/json;q=0.8": context.MIMEJSON,
+		"text/*":                            "text/xml",
+		"application/*;q=0.5, text/xml":     "text/xml",
+		"*/*, application/json;q=0":         context.MIMEXML,
+		"application/x-yaml; charset=utf-8": context.MIMEYAML,
+		"invalid, application/xml":          context.MIMEXML,
+	}
+	for accept, expected := range cases {
+		contentType, ok := context.Negotiate(accept, offers)
+		assert.True(t, ok, accept)
+		assert.Equal(t, expected, contentType, accept)
+	}
+
+	_, ok := context.Negotiate("text/html", offers)
+	assert.False(t, ok)
+	_, ok = context.Negotiate("application/json;q=0", offers)
+	assert.False(t, ok)
+	_, ok = context.Negotiate("", nil)
+	assert.False(t, ok)
+}
+
+func TestResponse_Negotiate(t *testing.T) {
+	r := New()
+	data := []userdto{{ID: 1, Name: "john"}, {ID: 2, Name: "jane, doe"}}
+	r.GET("/users", func(request *context.Request) context.IResponse {
+		return context.NewResponse(http.StatusOK).Negotiate(data)
+	})
+	r.GET("/export", func(request *context.Request) context.IResponse {
+		return context.NewResponse(http.StatusOK).Negotiate(data).Offer(csv.MIMECSV)
+	})
+	r.GET("/map", func(request *context.Request) context.IResponse {
+		return context.NewResponse(http.StatusOK).Negotiate(map[string]any{"id": 1})
+	})
+	r.Controller("/controller", new(negotiationcontroller), func(group *RouteController) {
+		group.GET("", "Index")
+	})
+
+	t.Run("formats", func(t *testing.T) {
+		recorder := call(r, http.MethodGet, "/users", "")
+		assert.Equal(t, context.MIMEJSON, recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "Accept", recorder.Header().Get("Vary"))
+		assert.JSONEq(t, `[{"id":1,"name":"john"},{"id":2,"name":"jane, doe"}]`, recorder.Body.String())
+
+		recorder = call(r, http.MethodGet, "/users", "", "Accept", "application/json;q=0.5, application/x-yaml")
+		assert.Equal(t, context.MIMEYAML, recorder.Header().Get("Content-Type"))
+		assert.Contains(t, recorder.Body.String(), "- id: 1")
+
+		recorder = call(r, http.MethodGet, "/controller", "", "Accept", "text/xml")
+		assert.Equal(t, "text/xml", recorder.Header().Get("Content-Type"))
+	})
+
+	t.Run("not acceptable", func(t *testing.T) {
+		recorder := call(r, http.MethodGet, "/users", "", "Accept", "text/html")
+		assert.Equal(t, http.StatusNotAcceptable, recorder.Code)
+
+		recorder = call(r, http.MethodGet, "/export", "", "Accept", context.MIMEJSON)
+		assert.Equal(t, http.StatusNotAcceptable, recorder.Code)
+	})
+
+	t.Run("fallback to default content type", func(t *testing.T) {
+		recorder := call(r, http.MethodGet, "/map", "", "Accept", context.MIMEXML)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, context.MIMEJSON, recorder.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"id":1}`, recorder.Body.String())
+	})
+
+	t.Run("custom renderer", func(t *testing.T) {
+		csv.Register()
+		recorder := call(r, http.MethodGet, "/export", "")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, csv.MIMECSV, recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "ID,Name\n1,john\n2,\"jane, doe\"\n", recorder.Body.String())
+
+		recorder = call(r, http.MethodGet, "/users", "", "Accept", "text/csv")
+		assert.Equal(t, csv.MIMECSV, recorder.Header().Get("Content-Type"))
+	})
+
+	t.Run("default content type", func(t *testing.T) {
+		context.SetDefaultContentType(context.MIMEXML)
+		defer context.SetDefaultContentType(context.MIMEJSON)
+		recorder := call(r, http.MethodGet, "/controller", "")
+		assert.Equal(t, context.MIMEXML, recorder.Header().Get("Content-Type"))
+		assert.Panics(t, func() { context.SetDefaultContentType("text/html") })
+	})
+
+	t.Run("errors are rendered leniently", func(t *testing.T) {
+		r := New()
+		r.GET("/:id", func(id int) {})
+		recorder := call(r, http.MethodGet, "/x", "", "Accept", "text/html")
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, context.MIMEJSON, recorder.Header().Get("Content-Type"))
+	})
+}
@@ -0,0 +1,50 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/web/binding"
+	"github.com/wardonne/gopi/web/context"
+	"github.com/wardonne/gopi/web/openapi"
+)
+
+func TestRouter_OpenAPI(t *testing.T) {
+	r := New()
+	r.SetValidateEngine(new(testvalidationengine))
+	handler := func(request *context.Request) context.IResponse {
+		return context.NewResponse(200)
+	}
+	r.Group("api", func(group *RouteGroup) {
+		group.POST("users", handler).AS("users.store").Validate(new(testform), binding.JSON)
+		group.GET("users/:id", handler).AS("users.show")
+	})
+	r.ServeOpenAPI("/openapi.json", openapi.Info{Title: "API", Version: "1.0.0"})
+
+	t.Run("serve", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		assert.Equal(t, 200, recorder.Code)
+
+		document := new(openapi.Document)
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), document))
+		assert.Equal(t, openapi.Version, document.OpenAPI)
+		assert.NotContains(t, document.Paths, "/openapi.json")
+		assert.Equal(t, "users.store", document.Paths["/api/users"]["post"].OperationID)
+		assert.Contains(t, document.Paths["/api/users"]["post"].RequestBody.Content["application/json"].Schema.Properties, "name")
+		assert.Equal(t, "id", document.Paths["/api/users/{id}"]["get"].Parameters[0].Name)
+	})
+
+	t.Run("dump", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "openapi.json")
+		assert.Nil(t, r.DumpOpenAPI(filename, openapi.Info{Title: "API", Version: "1.0.0"}))
+		content, err := os.ReadFile(filename)
+		assert.Nil(t, err)
+		assert.Contains(t, string(content), `"/api/users/{id}"`)
+	})
+}
@@ -15,7 +15,7 @@ func BenchmarkRouter_Handler(b *testing.B) {
 	r.GET("/get", func(request *context.Request) context.IResponse {
 		return context.NewResponse(200, "Hello World")
 	})
-	rr := r.HTTPRouter
+	rr := r.Compile().HTTPRouter
 	b.ResetTimer()
 	b.RunParallel(func(p *testing.PB) {
 		for p.Next() {
@@ -32,7 +32,7 @@ func BenchmarkRouter_HandlerWithValidate(b *testing.B) {
 	r.POST("/post", func(request *context.Request) context.IResponse {
 		return context.NewResponse(200, request.Validated().(*testform).Name)
 	}).Validate(new(testform), binding.JSON)
-	rr := r.HTTPRouter
+	rr := r.Compile().HTTPRouter
 	b.ResetTimer()
 	b.RunParallel(func(p *testing.PB) {
 		for p.Next() {
@@ -48,7 +48,7 @@ func BenchmarkRouter_Action(b *testing.B) {
 	r.Controller("api", new(testcontroller), func(group *RouteController) {
 		group.GET("get", "Index")
 	})
-	rr := r.HTTPRouter
+	rr := r.Compile().HTTPRouter
 	b.ResetTimer()
 	b.RunParallel(func(p *testing.PB) {
 		for p.Next() {
@@ -65,7 +65,7 @@ func BenchmarkRouter_ActionWithValidate(b *testing.B) {
 	r.Controller("api", new(testcontroller), func(group *RouteController) {
 		group.POST("post", "Valid").Validate(new(testform), binding.JSON)
 	})
-	rr := r.HTTPRouter
+	rr := r.Compile().HTTPRouter
 	b.ResetTimer()
 	b.RunParallel(func(p *testing.PB) {
 		for p.Next() {
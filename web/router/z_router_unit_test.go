@@ -66,7 +66,7 @@ func TestRouter_Handler(t *testing.T) {
 		group.TRACE("handler", handler).AS("TraceHandler")
 	})
 
-	rr := r.HTTPRouter
+	rr := r.Compile().HTTPRouter
 	methods := []string{
 		http.MethodGet,
 		http.MethodPost,
@@ -132,7 +132,7 @@ func TestRouter_HandlerWithMiddleware(t *testing.T) {
 		group.TRACE("handler", handler).Use(mw2).AS("TraceHandler")
 	}).Use(mw1)
 
-	rr := r.HTTPRouter
+	rr := r.Compile().HTTPRouter
 	methods := []string{
 		http.MethodGet,
 		http.MethodPost,
@@ -175,9 +175,10 @@ type testform struct {
 
 type testfilederror struct{ validator.FieldError }
 
-func (fe testfilederror) Tag() string   { return "required" }
-func (fe testfilederror) Field() string { return "Name" }
-func (fe testfilederror) Error() string { return "field required" }
+func (fe testfilederror) Tag() string                    { return "required" }
+func (fe testfilederror) Field() string                  { return "Name" }
+func (fe testfilederror) Error() string                  { return "field required" }
+func (fe testfilederror) Translate(ut.Translator) string { return fe.Error() }
 
 type testvalidationengine struct{}
 
@@ -225,7 +226,7 @@ func TestRouter_HandlerWithValidation(t *testing.T) {
 		group.TRACE("handler", handler).AS("TraceHandler").Validate(form)
 	})
 
-	rr := r.HTTPRouter
+	rr := r.Compile().HTTPRouter
 	methods := []string{
 		http.MethodGet,
 		http.MethodPost,
@@ -282,7 +283,7 @@ func TestRouter_Action(t *testing.T) {
 		group.TRACE("handler", "Index").AS("TraceAction")
 	})
 
-	rr := r.HTTPRouter
+	rr := r.Compile().HTTPRouter
 	methods := []string{
 		http.MethodGet,
 		http.MethodPost,
@@ -342,7 +343,7 @@ func TestRouter_ActionWithMiddleware(t *testing.T) {
 		group.TRACE("handler", "Index").AS("TraceAction").Use(mw2)
 	}).Use(mw1)
 
-	rr := r.HTTPRouter
+	rr := r.Compile().HTTPRouter
 	methods := []string{
 		http.MethodGet,
 		http.MethodPost,
@@ -394,7 +395,7 @@ func TestRouter_ActionWithValidation(t *testing.T) {
 		group.TRACE("handler", "Valid").AS("TraceAction").Validate(form)
 	})
 
-	rr := r.HTTPRouter
+	rr := r.Compile().HTTPRouter
 	methods := []string{
 		http.MethodGet,
 		http.MethodPost,
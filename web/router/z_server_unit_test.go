@@ -0,0 +1,73 @@
+package router
+
+import (
+	libctx "context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/web/context"
+)
+
+func TestRouter_ServeHTTP(t *testing.T) {
+	r := New()
+	r.GET("/ping", func(request *context.Request) context.IResponse {
+		return context.NewResponse(200, "pong")
+	})
+
+	t.Run("httptest.NewRecorder", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ping", nil))
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "pong", recorder.Body.String())
+	})
+
+	t.Run("httptest.NewServer", func(t *testing.T) {
+		server := httptest.NewServer(r.Handler())
+		defer server.Close()
+		resp, err := http.Get(server.URL + "/ping")
+		assert.Nil(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "pong", string(body))
+	})
+
+	t.Run("mounted in another mux", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.Handle("/", r)
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ping", nil))
+		assert.Equal(t, "pong", recorder.Body.String())
+	})
+}
+
+func TestRouter_ServeAndShutdown(t *testing.T) {
+	r := New().SetServerOptions(&ServerOptions{ReadTimeout: time.Second, WriteTimeout: time.Second})
+	r.GET("/ping", func(request *context.Request) context.IResponse {
+		return context.NewResponse(200, "pong")
+	})
+	assert.Nil(t, r.Shutdown(libctx.Background()))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Serve(listener)
+	}()
+
+	resp, err := http.Get("http://" + listener.Addr().String() + "/ping")
+	assert.Nil(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "pong", string(body))
+	assert.Equal(t, time.Second, r.Server().ReadTimeout)
+
+	ctx, cancel := libctx.WithTimeout(libctx.Background(), time.Second)
+	defer cancel()
+	assert.Nil(t, r.Shutdown(ctx))
+	assert.Nil(t, <-done)
+}
@@ -0,0 +1,139 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wardonne/gopi/web"
+	"github.com/wardonne/gopi/web/context"
+	"github.com/wardonne/gopi/web/middleware/signed"
+)
+
+type urlcontroller struct {
+	web.Controller
+}
+
+func (c *urlcontroller) Show() context.IResponse {
+	location, err := c.URL("users.show", map[string]any{"id": 2}, url.Values{"tab": {"posts"}})
+	if err != nil {
+		return context.NewResponse(500, err.Error())
+	}
+	return context.NewResponse(200, location)
+}
+
+func TestRouter_URL(t *testing.T) {
+	r := New()
+	handler := func(request *context.Request) context.IResponse {
+		return context.NewResponse(200)
+	}
+	r.GET("/users/:id", handler).AS("users.show")
+	r.GET("/users/:id/posts/:post", handler).AS("users.posts.show")
+	r.GET("/files/*path", handler).AS("files.show")
+
+	t.Run("named params", func(t *testing.T) {
+		location, err := r.URL("users.posts.show", map[string]any{"id": 1, "post": "a b"}, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "/users/1/posts/a%20b", location)
+	})
+
+	t.Run("catch-all param", func(t *testing.T) {
+		location, err := r.URL("files.show", map[string]any{"path": "/docs/read me.md"}, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "/files/docs/read%20me.md", location)
+	})
+
+	t.Run("query", func(t *testing.T) {
+		location, err := r.URL("users.show", map[string]any{"id": 1}, url.Values{"tab": {"posts"}, "q": {"a&b"}})
+		assert.Nil(t, err)
+		assert.Equal(t, "/users/1?q=a%26b&tab=posts", location)
+	})
+
+	t.Run("missing param", func(t *testing.T) {
+		_, err := r.URL("users.posts.show", map[string]any{"id": 1}, nil)
+		assert.ErrorIs(t, err, ErrMissingRouteParam)
+	})
+
+	t.Run("route not found", func(t *testing.T) {
+		_, err := r.URL("users.missing", nil, nil)
+		assert.ErrorIs(t, err, ErrRouteNotFound)
+	})
+
+	t.Run("from controller", func(t *testing.T) {
+		r.Controller("/profiles", new(urlcontroller), func(group *RouteController) {
+			group.GET(":id", "Show")
+		})
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/profiles/1", nil))
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "/users/2?tab=posts", recorder.Body.String())
+	})
+}
+
+func TestRouter_SignedURL(t *testing.T) {
+	r := New()
+	_, err := r.SignedURL("files.download", nil, nil, time.Time{})
+	assert.ErrorIs(t, err, ErrSigningKeyEmpty)
+
+	r.SetSigningKey([]byte("secret"))
+	r.GET("/files/:id", func(request *context.Request) context.IResponse {
+		return context.NewResponse(200, "ok")
+	}).AS("files.download").Use(r.SignedMiddleware())
+	r.GET("/docs/*path", func(request *context.Request) context.IResponse {
+		return context.NewResponse(200, request.Param("path"))
+	}).AS("docs.show").Use(r.SignedMiddleware())
+
+	request := func(target string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+		return recorder
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		location, err := r.SignedURL("files.download", map[string]any{"id": 1}, url.Values{"inline": {"1"}}, time.Now().Add(time.Hour))
+		assert.Nil(t, err)
+		assert.Equal(t, 200, request(location).Code)
+	})
+
+	t.Run("escaped params", func(t *testing.T) {
+		for _, path := range []string{"read me.md", "résumé/履歴書.pdf"} {
+			location, err := r.SignedURL("docs.show", map[string]any{"path": path}, nil, time.Now().Add(time.Hour))
+			assert.Nil(t, err)
+			recorder := request(location)
+			assert.Equal(t, 200, recorder.Code, location)
+			assert.Equal(t, "/"+path, recorder.Body.String())
+		}
+	})
+
+	t.Run("without expiration", func(t *testing.T) {
+		location, err := r.SignedURL("files.download", map[string]any{"id": 1}, nil, time.Time{})
+		assert.Nil(t, err)
+		assert.NotContains(t, location, signed.ExpiresKey)
+		assert.Equal(t, 200, request(location).Code)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		location, err := r.SignedURL("files.download", map[string]any{"id": 1}, nil, time.Now().Add(time.Hour))
+		assert.Nil(t, err)
+		u, _ := url.Parse(location)
+		u.Path = "/files/2"
+		recorder := request(u.String())
+		assert.Equal(t, 403, recorder.Code)
+		assert.Equal(t, signed.ErrInvalidSignature.Error(), recorder.Body.String())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		location, err := r.SignedURL("files.download", map[string]any{"id": 1}, nil, time.Now().Add(-time.Minute))
+		assert.Nil(t, err)
+		recorder := request(location)
+		assert.Equal(t, 403, recorder.Code)
+		assert.Equal(t, signed.ErrSignatureExpired.Error(), recorder.Body.String())
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		assert.Equal(t, 403, request("/files/1").Code)
+	})
+}
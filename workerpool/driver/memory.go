@@ -85,12 +85,21 @@ func (driver *MemoryDriver) Fail(job job.Interface) {
 	driver.failedJobs.Push(job)
 }
 
+// Failed returns the failed jobs
+func (driver *MemoryDriver) Failed() []job.Interface {
+	return driver.failedJobs.ToArray()
+}
+
 // Flush removes all failed jobs
 func (driver *MemoryDriver) Flush() {
+	driver.failedJobs.Clear()
 }
 
 // Reload reloads all failed jobs into queue
 func (driver *MemoryDriver) Reload() {
+	for !driver.failedJobs.IsEmpty() {
+		driver.Enqueue(driver.failedJobs.Shift())
+	}
 }
 
 // Subscribe add a subscriber to queue events
@@ -145,6 +145,11 @@ func (wp *WorkerPool) StoppedAt() time.Time {
 	return wp.stoppedAt
 }
 
+// Driver returns the driver of the WorkerPool
+func (wp *WorkerPool) Driver() driver.IDriver {
+	return wp.driver
+}
+
 // IsRunning returns whether the WorkerPool is running
 func (wp *WorkerPool) IsRunning() bool {
 	return wp.status == WorkerPoolStatusRunning